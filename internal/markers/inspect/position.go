@@ -0,0 +1,139 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/parser"
+)
+
+// Position is the position of a marker, by line and column starting from 1, within the
+// inspected YAML content.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// String returns the position in line:column format.
+func (position Position) String() string {
+	return fmt.Sprintf("%d:%d", position.Line, position.Column)
+}
+
+// PositionError is an error which was found for a marker at a position within the inspected
+// YAML content.  The text of the marker is kept so that the marker may be found again once
+// the content has been transformed.
+type PositionError struct {
+	Position   Position
+	MarkerText string
+	Err        error
+}
+
+// NewPositionError returns an error which was found for the marker of a result.  Errors which
+// already have a position are returned as is.
+func NewPositionError(err error, result *YAMLResult) error {
+	var positionErr *PositionError
+	if errors.As(err, &positionErr) {
+		return err
+	}
+
+	return &PositionError{
+		Position:   result.Position,
+		MarkerText: result.MarkerText,
+		Err:        err,
+	}
+}
+
+func (e *PositionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// PositionErrors are the errors which were found for more than one marker within the inspected
+// YAML content.  Each of the errors has a position.
+type PositionErrors []error
+
+func (errs PositionErrors) Error() string {
+	messages := make([]string, len(errs))
+
+	for i := range errs {
+		messages[i] = errs[i].Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// ErrorOrNil returns the single error which was found, the errors if more than one error was
+// found, or nil if no errors were found.
+func (errs PositionErrors) ErrorOrNil() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// commentSource is the comment of a node, along with the lines of the inspected content, which
+// is used to find the position of the markers within the comment.
+type commentSource struct {
+	lines []string
+	node  *yaml.Node
+}
+
+// position returns the position of a marker within the inspected content.  The parser only
+// knows the position of the marker within the head, line and foot comments of the node, which
+// are joined by a new line, so the line of the comment is found within the content nearest to
+// the node and the column is offset from the start of the comment.
+func (source *commentSource) position(result *parser.Result) Position {
+	head := strings.Split(source.node.HeadComment, "\n")
+
+	// find the line at which the comment is expected to be found within the content
+	var expected int
+
+	switch {
+	case result.Line <= len(head):
+		expected = source.node.Line - len(head) + result.Line - 1
+	case result.Line == len(head)+1:
+		expected = source.node.Line
+	default:
+		expected = source.node.Line + result.Line - len(head) - 1
+	}
+
+	comments := strings.Split(
+		fmt.Sprintf("%s\n%s\n%s", source.node.HeadComment, source.node.LineComment, source.node.FootComment),
+		"\n",
+	)
+
+	if result.Line < 1 || result.Line > len(comments) || strings.TrimSpace(comments[result.Line-1]) == "" {
+		return Position{Line: expected, Column: result.Column}
+	}
+
+	comment := comments[result.Line-1]
+
+	// search outwards from the expected line as the comments of a node may be separated from
+	// it, e.g. by blank lines
+	for distance := 0; distance < len(source.lines); distance++ {
+		for _, line := range []int{expected - distance, expected + distance} {
+			if line < 1 || line > len(source.lines) {
+				continue
+			}
+
+			if index := strings.Index(source.lines[line-1], comment); index >= 0 {
+				return Position{Line: line, Column: index + result.Column}
+			}
+		}
+	}
+
+	return Position{Line: expected, Column: result.Column}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -16,7 +17,8 @@ import (
 
 type YAMLResult struct {
 	*parser.Result
-	Nodes []*yaml.Node
+	Nodes    []*yaml.Node
+	Position Position
 }
 
 func (s *Inspector) InspectYAML(data []byte, transforms ...YAMLTransformer) ([]*yaml.Node, []*YAMLResult, error) {
@@ -38,69 +40,83 @@ func (s *Inspector) InspectYAML(data []byte, transforms ...YAMLTransformer) ([]*
 
 	var results []*YAMLResult
 
+	lines := strings.Split(string(data), "\n")
+
 	for _, node := range nodes {
-		docResults := s.inspectYAML(node)
+		docResults := s.inspectYAML(lines, node)
 
 		results = append(results, docResults...)
 	}
 
+	// report the errors of all of the markers at once, including the errors of the markers
+	// which could be parsed but not transformed
+	var errs PositionErrors
+
 	for _, result := range results {
 		if v, ok := result.Result.Object.(error); ok {
-			return nodes, results, v
+			errs = append(errs, NewPositionError(v, result))
 		}
 	}
 
 	for _, transform := range transforms {
 		if err := transform(results...); err != nil {
-			return nodes, nil, err
+			var transformErrs PositionErrors
+			if errors.As(err, &transformErrs) {
+				errs = append(errs, transformErrs...)
+			} else {
+				errs = append(errs, err)
+			}
 		}
 	}
 
+	if err := errs.ErrorOrNil(); err != nil {
+		return nodes, results, err
+	}
+
 	return nodes, results, nil
 }
 
-func (s *Inspector) inspectYAML(nodes ...*yaml.Node) (results []*YAMLResult) {
+func (s *Inspector) inspectYAML(lines []string, nodes ...*yaml.Node) (results []*YAMLResult) {
 	for _, node := range nodes {
-		results = append(results, s.inspectYAMLComments(node)...)
+		results = append(results, s.inspectYAMLComments(lines, node)...)
 
 		if node.Kind == yaml.MappingNode {
-			results = append(results, s.inspectYAMLMap(node.Content...)...)
+			results = append(results, s.inspectYAMLMap(lines, node.Content...)...)
 		} else if node.Content != nil {
-			results = append(results, s.inspectYAML(node.Content...)...)
+			results = append(results, s.inspectYAML(lines, node.Content...)...)
 		}
 	}
 
 	return results
 }
 
-func (s *Inspector) inspectYAMLMap(nodes ...*yaml.Node) (results []*YAMLResult) {
+func (s *Inspector) inspectYAMLMap(lines []string, nodes ...*yaml.Node) (results []*YAMLResult) {
 	for i := 0; i < len(nodes); i += 2 {
-		results = append(results, s.inspectYAMLComments(nodes[i], nodes[i+1])...)
+		results = append(results, s.inspectYAMLComments(lines, nodes[i], nodes[i+1])...)
 
 		if nodes[i+1].Kind == yaml.MappingNode {
-			results = append(results, s.inspectYAMLMap(nodes[i+1].Content...)...)
+			results = append(results, s.inspectYAMLMap(lines, nodes[i+1].Content...)...)
 		} else {
-			results = append(results, s.inspectYAML(nodes[i+1].Content...)...)
+			results = append(results, s.inspectYAML(lines, nodes[i+1].Content...)...)
 		}
 	}
 
 	return results
 }
 
-func (s *Inspector) inspectYAMLComments(nodes ...*yaml.Node) (results []*YAMLResult) {
-	var markers []*parser.Result
-
+func (s *Inspector) inspectYAMLComments(lines []string, nodes ...*yaml.Node) (results []*YAMLResult) {
 	for _, node := range nodes {
-		markers = append(markers, s.parse(fmt.Sprintf("%s\n%s\n%s", node.HeadComment, node.LineComment, node.FootComment))...)
-	}
+		source := &commentSource{lines: lines, node: node}
 
-	for _, marker := range markers {
-		result := &YAMLResult{
-			Result: marker,
-			Nodes:  nodes,
-		}
+		for _, marker := range s.parse(fmt.Sprintf("%s\n%s\n%s", node.HeadComment, node.LineComment, node.FootComment)) {
+			result := &YAMLResult{
+				Result:   marker,
+				Nodes:    nodes,
+				Position: source.position(marker),
+			}
 
-		results = append(results, result)
+			results = append(results, result)
+		}
 	}
 
 	return results
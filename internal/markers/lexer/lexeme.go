@@ -47,6 +47,8 @@ const (
 	literalQuote    = "`"
 	doubleQuote     = `"`
 	singleQuote     = `'`
+	sliceTypePrefix = "[]"
+	mapTypePrefix   = "map[string]"
 )
 
 func (l Lexeme) String() string {
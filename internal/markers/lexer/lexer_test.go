@@ -185,6 +185,25 @@ func TestLexer(t *testing.T) {
 				{Type: lexer.LexemeEOF, Value: ""},
 			},
 		},
+		{
+			name:  "marker with two scopes and a flag followed by another arg",
+			input: "+galaxy:planet:current-location,name=earth",
+			expected: []lexer.Lexeme{
+				{Type: lexer.LexemeMarkerStart, Value: "+"},
+				{Type: lexer.LexemeScope, Value: "galaxy"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeScope, Value: "planet"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeArg, Value: "current-location"},
+				{Type: lexer.LexemeSyntheticBoolLiteral, Value: "true"},
+				{Type: lexer.LexemeArgDelimiter, Value: ","},
+				{Type: lexer.LexemeArg, Value: "name"},
+				{Type: lexer.LexemeArgAssignment, Value: "="},
+				{Type: lexer.LexemeStringLiteral, Value: "earth"},
+				{Type: lexer.LexemeMarkerEnd, Value: "\n"},
+				{Type: lexer.LexemeEOF, Value: ""},
+			},
+		},
 		{
 			name:  "marker with single quoted string arg",
 			input: "+galaxy:name=milkyway,description='our home system'",
@@ -400,6 +419,51 @@ func TestLexer(t *testing.T) {
 				{Type: lexer.LexemeEOF, Value: ""},
 			},
 		},
+		{
+			name:  "marker with slice type literal arg",
+			input: `# +operator-builder:field:name=args,type=[]string`,
+			expected: []lexer.Lexeme{
+				{Type: lexer.LexemeComment, Value: "#"},
+				{Type: lexer.LexemeMarkerStart, Value: "+"},
+				{Type: lexer.LexemeScope, Value: "operator-builder"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeScope, Value: "field"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeArg, Value: "name"},
+				{Type: lexer.LexemeArgAssignment, Value: "="},
+				{Type: lexer.LexemeStringLiteral, Value: "args"},
+				{Type: lexer.LexemeArgDelimiter, Value: ","},
+				{Type: lexer.LexemeArg, Value: "type"},
+				{Type: lexer.LexemeArgAssignment, Value: "="},
+				{Type: lexer.LexemeStringLiteral, Value: "[]string"},
+				{Type: lexer.LexemeMarkerEnd, Value: "\n"},
+				{Type: lexer.LexemeEOF, Value: ""},
+			},
+		},
+		{
+			name:  "marker with map type literal arg",
+			input: `# +operator-builder:field:name=labels,type=map[string]string,merge`,
+			expected: []lexer.Lexeme{
+				{Type: lexer.LexemeComment, Value: "#"},
+				{Type: lexer.LexemeMarkerStart, Value: "+"},
+				{Type: lexer.LexemeScope, Value: "operator-builder"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeScope, Value: "field"},
+				{Type: lexer.LexemeSeparator, Value: ":"},
+				{Type: lexer.LexemeArg, Value: "name"},
+				{Type: lexer.LexemeArgAssignment, Value: "="},
+				{Type: lexer.LexemeStringLiteral, Value: "labels"},
+				{Type: lexer.LexemeArgDelimiter, Value: ","},
+				{Type: lexer.LexemeArg, Value: "type"},
+				{Type: lexer.LexemeArgAssignment, Value: "="},
+				{Type: lexer.LexemeStringLiteral, Value: "map[string]string"},
+				{Type: lexer.LexemeArgDelimiter, Value: ","},
+				{Type: lexer.LexemeArg, Value: "merge"},
+				{Type: lexer.LexemeSyntheticBoolLiteral, Value: "true"},
+				{Type: lexer.LexemeMarkerEnd, Value: "\n"},
+				{Type: lexer.LexemeEOF, Value: ""},
+			},
+		},
 	}
 
 	focused := false
@@ -14,6 +14,16 @@ type position struct {
 	column int
 }
 
+// Line returns the line of the position, starting from 1.
+func (p position) Line() int {
+	return p.line
+}
+
+// Column returns the column of the position, starting from 1.
+func (p position) Column() int {
+	return p.column
+}
+
 // next returns the next rune in the input.
 func (l *Lexer) next() (r rune) {
 	var err error
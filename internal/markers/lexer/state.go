@@ -100,6 +100,15 @@ func lexMarker(l *Lexer) stateFn {
 		l.emitSynthetic(LexemeMarkerEnd, "\n")
 
 		return lexComment
+	case l.peeked(argDelimiter):
+		if l.lastEmittedLexeme.Type != LexemeSeparator {
+			return l.warningf(`marker without scope found`)
+		}
+
+		l.emit(LexemeArg)
+		l.emitSynthetic(LexemeSyntheticBoolLiteral, "true")
+
+		return lexMoreArgs
 	case l.peeked(argAssignment):
 		if l.lastEmittedLexeme.Type != LexemeSeparator {
 			return l.warningf(`marker without scope found`)
@@ -166,6 +175,10 @@ func lexArgValueInitial(l *Lexer) stateFn {
 		return nextState
 	}
 
+	if nextState, present := lexTypeLiteral(l, lexMoreArgs); present {
+		return nextState
+	}
+
 	if nextState, present := lexNakedStringLiteral(l, lexMoreArgs); present {
 		return nextState
 	}
@@ -301,6 +314,32 @@ func lexNakedStringLiteral(l *Lexer, nextState stateFn) (stateFn, bool) {
 	return nextState, true
 }
 
+// lexTypeLiteral scans a go type literal (e.g. []string or map[string]string) which
+// would otherwise be rejected as a naked string literal because it contains brackets.
+func lexTypeLiteral(l *Lexer, nextState stateFn) (stateFn, bool) {
+	if !l.peeked(sliceTypePrefix) && !l.peeked(mapTypePrefix) {
+		return nil, false
+	}
+
+	for l.consumed(sliceTypePrefix) || l.consumed(mapTypePrefix) {
+		continue
+	}
+
+	exceptions := []rune{
+		':', '=', ' ', '"', '\'', '`',
+		',', '+', '{', '}', '[', ']',
+		'(', ')', '\n', eof,
+	}
+
+	if elementType := l.consumeUntil(exceptions...); !elementType {
+		return l.errorf("malformed type literal: %s", l.buffer), true
+	}
+
+	l.emit(LexemeStringLiteral)
+
+	return nextState, true
+}
+
 func lexMoreArgs(l *Lexer) stateFn {
 	switch {
 	case l.consumed(argDelimiter):
@@ -107,7 +107,7 @@ func (a *Argument) SetValue(value interface{}) error {
 
 		return fmt.Errorf("%w, cannot convert %v to string", ErrUnmarshal, value)
 	case a.Pointer:
-		if !reflect.TypeOf(value).ConvertibleTo(a.Type.Elem()) {
+		if !reflect.TypeOf(value).AssignableTo(a.Type.Elem()) {
 			return fmt.Errorf("%w, wanted %q but received %q", ErrWrongType, a.Type.Elem(), reflect.TypeOf(value))
 		}
 
@@ -116,7 +116,7 @@ func (a *Argument) SetValue(value interface{}) error {
 		a.isSet = true
 
 		return nil
-	case !reflect.TypeOf(value).ConvertibleTo(a.Type):
+	case !reflect.TypeOf(value).AssignableTo(a.Type):
 		return fmt.Errorf("%w, wanted %q but received %q", ErrWrongType, a.Type, reflect.TypeOf(value))
 	default:
 		a.Value.Set(reflect.ValueOf(value))
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/parser"
 )
 
 var (
@@ -48,6 +50,18 @@ func (m *Definition) LookupArgument(argName string) bool {
 	return found
 }
 
+// SuggestArgument returns the names of the arguments of the marker which an unknown argument
+// name is most likely a typo of.
+func (m *Definition) SuggestArgument(argName string) []string {
+	names := make([]string, 0, len(m.Fields))
+
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+
+	return parser.Suggest(argName, names)
+}
+
 func (m *Definition) SetArgument(argName string, value interface{}) error {
 	if arg, found := m.Fields[argName]; found {
 		if err := arg.SetValue(value); err != nil {
@@ -59,7 +73,7 @@ func (m *Definition) SetArgument(argName string, value interface{}) error {
 		return nil
 	}
 
-	return fmt.Errorf("%w %q for marker %s", ErrArgNotFound, argName, m.Name)
+	return fmt.Errorf("%w %q for marker %s%s", ErrArgNotFound, argName, m.Name, parser.DidYouMean(m.SuggestArgument(argName)))
 }
 
 func (m *Definition) InflateObject() (interface{}, error) {
@@ -27,6 +27,18 @@ func (r *Registry) Lookup(name string) bool {
 	return found
 }
 
+// Suggest returns the names of the markers within the registry which an unknown marker name is
+// most likely a typo of.
+func (r *Registry) Suggest(name string) []string {
+	names := make([]string, 0, len(r.registry))
+
+	for registered := range r.registry {
+		names = append(names, registered)
+	}
+
+	return parser.Suggest(name, names)
+}
+
 func (r *Registry) GetDefinition(name string) parser.Definition {
 	m := r.registry[name]
 
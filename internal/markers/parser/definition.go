@@ -6,6 +6,7 @@ package parser
 type Definition interface {
 	GetName() string
 	LookupArgument(name string) bool
+	SuggestArgument(name string) []string
 	SetArgument(name string, value interface{}) error
 	InflateObject() (interface{}, error)
 }
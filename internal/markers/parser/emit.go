@@ -13,7 +13,10 @@ func (p *Parser) emit() error {
 
 	result := &Result{
 		Object:     output,
+		MarkerName: p.currentDefinition.GetName(),
 		MarkerText: p.scopeBuffer,
+		Line:       p.markerLine,
+		Column:     p.markerColumn,
 	}
 
 	p.items <- result
@@ -23,7 +26,13 @@ func (p *Parser) emit() error {
 	return nil
 }
 
+// Result is a marker, or an error for a marker, which was found by the parser.  The line and
+// column are the position at which the marker, or the error, was found within the input.  The
+// marker name is the name of the definition which the marker was parsed with.
 type Result struct {
 	Object     interface{}
+	MarkerName string
 	MarkerText string
+	Line       int
+	Column     int
 }
@@ -3,19 +3,41 @@
 
 package parser
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownMarker   = errors.New("unknown marker")
+	ErrUnknownArgument = errors.New("unknown argument")
+)
 
 func (p *Parser) error(err error) stateFn {
 	var markerName string
 
-	if p.currentDefinition != nil {
+	switch {
+	case p.currentDefinition != nil:
 		markerName = p.currentDefinition.GetName()
-	} else {
+	case p.scopeBuffer != "":
+		markerName = strings.TrimSuffix(p.scopeBuffer, ":")
+	default:
 		markerName = "Unknown Marker"
 	}
+
+	// errors are reported at the position of the lexeme which caused them, unless the lexeme
+	// was not found within the input or the marker itself is unknown
+	line, column := p.currentLexeme.Pos.Line(), p.currentLexeme.Pos.Column()
+	if line == 0 || errors.Is(err, ErrUnknownMarker) {
+		line, column = p.markerLine, p.markerColumn
+	}
+
 	p.items <- &Result{
-		Object:     fmt.Errorf("%w, on marker %s at %+v", err, markerName, p.currentLexeme.Pos),
+		Object:     fmt.Errorf("%w, on marker %s", err, markerName),
 		MarkerText: p.scopeBuffer,
+		Line:       line,
+		Column:     column,
 	}
 
 	return nil
@@ -18,6 +18,8 @@ type Parser struct {
 	registry          Registry
 	currentLexeme     lexer.Lexeme
 	currentDefinition Definition
+	markerLine        int
+	markerColumn      int
 	peekCount         int
 	peekStack         [3]lexer.Lexeme
 	stack             []stateFn
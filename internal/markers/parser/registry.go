@@ -6,4 +6,5 @@ package parser
 type Registry interface {
 	Lookup(name string) bool
 	GetDefinition(name string) Definition
+	Suggest(name string) []string
 }
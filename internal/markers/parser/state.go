@@ -5,6 +5,7 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/lexer"
@@ -46,6 +47,9 @@ func parse(p *Parser) stateFn {
 }
 
 func parseMarkerStart(p *Parser) stateFn {
+	// track the position of the marker so that it may be reported along with its result
+	p.markerLine, p.markerColumn = p.currentLexeme.Pos.Line(), p.currentLexeme.Pos.Column()
+
 	if p.consumed(lexer.LexemeScope) {
 		return parseScope
 	}
@@ -69,6 +73,17 @@ func parseSeparator(p *Parser) stateFn {
 		if found := p.loadDefinition(); found {
 			return parseArg
 		}
+
+		// markers which are not found within the registry are skipped, as they may be found within
+		// another registry, unless they are near enough to a marker which is found within the
+		// registry that they are most likely a typo of it
+		name := p.scopeBuffer[:len(p.scopeBuffer)-1]
+
+		if suggestions := p.registry.Suggest(name); len(suggestions) > 0 {
+			p.currentDefinition = nil
+
+			return p.error(fmt.Errorf("%w%s", ErrUnknownMarker, DidYouMean(suggestions)))
+		}
 	}
 
 	p.flush()
@@ -78,15 +93,19 @@ func parseSeparator(p *Parser) stateFn {
 
 func parseArg(p *Parser) stateFn {
 	if p.consumed(lexer.LexemeArg) {
-		if found := p.currentDefinition.LookupArgument(p.currentLexeme.Value); found {
-			argName := p.currentLexeme.Value
+		argName := p.currentLexeme.Value
 
-			if p.peeked(lexer.LexemeArgAssignment) {
-				p.next()
-			}
+		if found := p.currentDefinition.LookupArgument(argName); !found {
+			return p.error(fmt.Errorf(
+				"%w %q%s", ErrUnknownArgument, argName, DidYouMean(p.currentDefinition.SuggestArgument(argName)),
+			))
+		}
 
-			return parseArgValue(p, argName)
+		if p.peeked(lexer.LexemeArgAssignment) {
+			p.next()
 		}
+
+		return parseArgValue(p, argName)
 	}
 
 	return parse
@@ -128,7 +147,7 @@ func parseArgValue(p *Parser, argName string) stateFn {
 			return p.error(err)
 		}
 	case p.consumed(lexer.LexemeFloatLiteral):
-		const floatSize = 32
+		const floatSize = 64
 
 		v, err := strconv.ParseFloat(p.currentLexeme.Value, floatSize)
 		if err != nil {
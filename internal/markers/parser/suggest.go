@@ -0,0 +1,102 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown name and a known name
+// for the known name to be suggested in its place.  The names of the known markers are further
+// apart than this, so that a known marker is never mistaken for a typo of another one.
+const maxSuggestionDistance = 2
+
+// Suggest returns the candidates which are nearest to an unknown name, by edit distance, as long
+// as they are near enough to the name for it to most likely be a typo of them.
+func Suggest(name string, candidates []string) []string {
+	var suggestions []string
+
+	nearest := maxSuggestionDistance + 1
+
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+
+		switch {
+		case distance > nearest || distance == 0:
+			continue
+		case distance < nearest:
+			nearest = distance
+			suggestions = []string{candidate}
+		default:
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.Strings(suggestions)
+
+	return suggestions
+}
+
+// DidYouMean returns a hint which suggests known names in place of an unknown name, or an empty
+// string if there are no suggestions.
+func DidYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("; did you mean %q?", suggestions[0])
+	default:
+		quoted := make([]string, len(suggestions))
+
+		for i := range suggestions {
+			quoted[i] = fmt.Sprintf("%q", suggestions[i])
+		}
+
+		return fmt.Sprintf("; did you mean one of [%s]?", strings.Join(quoted, ", "))
+	}
+}
+
+// editDistance returns the Levenshtein distance between two strings, which is the number of
+// single rune insertions, deletions or substitutions needed to change one into the other.
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = minimum(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+func minimum(values ...int) int {
+	result := values[0]
+
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
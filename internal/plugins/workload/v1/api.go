@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
@@ -25,6 +26,7 @@ type createAPISubcommand struct {
 
 	workloadConfigPath string
 	cliRootCommandName string
+	maxErrors          int
 	workload           kinds.WorkloadBuilder
 }
 
@@ -40,6 +42,10 @@ func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdM
 `, cliMeta.CommandName)
 }
 
+func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&p.maxErrors, "max-errors", 0, "maximum number of errors to report, or 0 to report all errors")
+}
+
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
@@ -66,7 +72,7 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI, p.workloadConfigPath, err)
 	}
 
-	if err := subcommand.CreateAPI(processor); err != nil {
+	if err := subcommand.CreateAPI(processor, p.maxErrors); err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI, p.workloadConfigPath, err)
 	}
 
@@ -137,7 +137,7 @@ func (s *apiScaffolder) scaffoldWorkload(
 	if err := scaffold.Execute(
 		&controller.Controller{Builder: workload},
 		&controller.Phases{PackageName: workload.GetPackageName()},
-		&dependencies.Component{},
+		&dependencies.Component{Builder: workload},
 		&mutate.Component{},
 		&crd.Kustomization{},
 	); err != nil {
@@ -47,6 +47,12 @@ const definitionTemplate = `{{ .Boilerplate }}
 package {{ .Builder.GetPackageName }}
 
 import (
+	{{ if or (ne (len .Manifest.StatusFuncNames) 0) (ne (len .Manifest.ReadyFuncNames) 0) }}"context"{{ end }}
+	{{ if .Manifest.UsesPackage "fmt" }}"fmt"{{ end }}
+	{{ if .Manifest.UsesPackage "strconv" }}"strconv"{{ end }}
+
+	{{ if or (ne (len .Manifest.StatusFuncNames) 0) (ne (len .Manifest.ReadyFuncNames) 0) }}apierrs "k8s.io/apimachinery/pkg/api/errors"{{ end }}
+	{{ if ne (len .Manifest.ReadyFuncNames) 0 }}metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"{{ end }}
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -62,7 +68,7 @@ import (
 {{ end }}
 {{ if ne .NameConstant "" }}const {{ .UniqueName }} = "{{ .NameConstant }}"{{ end }}
 
-// {{ .CreateFuncName }} creates the {{ .Name }} {{ .Kind }} resource.
+// {{ .CreateFuncName }} creates the {{ if ne .BaseName "" }}{{ .BaseName }} {{ end }}{{ .Kind }} resource.
 func {{ .CreateFuncName }} (
 	parent *{{ $.Resource.ImportAlias }}.{{ $.Resource.Kind }},
 	{{ if $.Builder.IsComponent -}}
@@ -73,16 +79,129 @@ func {{ .CreateFuncName }} (
 	{{- if ne .IncludeCode "" }}{{ .IncludeCode }}{{ end }}
 
 	resourceObjs := []client.Object{}
+	{{- if ne .RepeatCode "" }}
+	{{ .RepeatCode }}
+	{{- end }}
 
 	{{- .SourceCode }}
+	{{- .OptionalFieldCode }}
+	{{- .IncludeFieldCode }}
 
 	{{ if not $.Builder.IsClusterScoped }}
 	resourceObj.SetNamespace(parent.Namespace)
+	{{ end }}
+	{{- if .CreateOnly }}
+	// the resource is created once and never updated by the reconciler
+	setPolicyAnnotation(resourceObj, CreateOnlyAnnotation, "true")
+
+	{{ end }}
+	{{- if ne (len .IgnoreFields) 0 }}
+	// the fields of the resource which are changed by others are not updated by the reconciler
+	setPolicyAnnotation(resourceObj, IgnoreFieldsAnnotation, {{ printf "%q" .IgnoreFieldsValue }})
+
+	{{ end }}
+	{{- if .IsReleasedOnDeletion }}
+	// the resource is released, rather than deleted, when the parent is deleted
+	setPolicyAnnotation(resourceObj, DeletionPolicyAnnotation, "{{ .DeletionPolicy }}")
+
 	{{ end }}
 
 	resourceObjs = append(resourceObjs, resourceObj)
+	{{- if ne .RepeatCode "" }}
+	}
+	{{- end }}
 
 	return resourceObjs, nil
 }
+{{ if ne .StatusFuncName "" }}
+// {{ .StatusFuncName }} sets the status fields of the parent which are projected from the
+// live {{ .Kind }} resource created by {{ .CreateFuncName }}.
+func {{ .StatusFuncName }} (
+	ctx context.Context,
+	reader client.Reader,
+	parent *{{ $.Resource.ImportAlias }}.{{ $.Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	collection *{{ $.Builder.GetCollection.Spec.API.Group }}{{ $.Builder.GetCollection.Spec.API.Version }}.{{ $.Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) error {
+	{{ if $.Builder.IsComponent -}}
+	resourceObjs, err := {{ .CreateFuncName }}(parent, collection)
+	{{ else -}}
+	resourceObjs, err := {{ .CreateFuncName }}(parent)
+	{{ end -}}
+	if err != nil {
+		return err
+	}
+
+	for _, resourceObj := range resourceObjs {
+		liveObj := &unstructured.Unstructured{}
+		liveObj.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(resourceObj), liveObj); err != nil {
+			// a resource which has not yet been created has no status to project, while the
+			// remaining resources (e.g. those of a repeat marker) may still be projected
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+		{{- range .StatusMarkers }}
+
+		if err := setStatusField(liveObj, &parent.Status.{{ .GetFieldName }}, {{ .GetSourcePathCode }}); err != nil {
+			return err
+		}
+		{{- end }}
+	}
+
+	return nil
+}
+{{ end }}
+{{- if ne .ReadyFuncName "" }}
+// {{ .ReadyFuncName }} returns the ready conditions of the live {{ .Kind }} resources created
+// by {{ .CreateFuncName }}, as checked by the ready markers of the resource.
+func {{ .ReadyFuncName }} (
+	ctx context.Context,
+	reader client.Reader,
+	parent *{{ $.Resource.ImportAlias }}.{{ $.Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	collection *{{ $.Builder.GetCollection.Spec.API.Group }}{{ $.Builder.GetCollection.Spec.API.Version }}.{{ $.Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) ([]metav1.Condition, error) {
+	{{ if $.Builder.IsComponent -}}
+	resourceObjs, err := {{ .CreateFuncName }}(parent, collection)
+	{{ else -}}
+	resourceObjs, err := {{ .CreateFuncName }}(parent)
+	{{ end -}}
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []metav1.Condition{}
+
+	for _, resourceObj := range resourceObjs {
+		liveObj := &unstructured.Unstructured{}
+		liveObj.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(resourceObj), liveObj); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+
+			conditions = append(conditions, newReadyCondition(resourceObj, metav1.ConditionFalse, "NotFound", "resource does not exist"))
+
+			continue
+		}
+
+		conditions = append(conditions, checkReady(liveObj,
+			{{- range .ReadyCheckCode }}
+			{{ . }},
+			{{- end }}
+		))
+	}
+
+	return conditions, nil
+}
+{{ end }}
 {{ end }}
 `
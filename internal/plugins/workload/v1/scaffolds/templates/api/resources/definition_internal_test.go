@@ -0,0 +1,426 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cfgv3 "sigs.k8s.io/kubebuilder/v3/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/commands/subcommand"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/config"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
+)
+
+const testDefinitionWorkload = `name: test
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com
+    group: apps
+    version: v1alpha1
+    kind: Test
+    clusterScoped: false
+  resources:
+  - resources.yaml
+`
+
+func TestDefinition_imports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+		notWant  []string
+		wantCode []string
+	}{
+		{
+			name: "ensure definition without conversions or templates does not import fmt or strconv",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  name: test  # +operator-builder:field:name=name,type=string,default="test",replace="test"
+`,
+			notWant: []string{"fmt", "strconv"},
+		},
+		{
+			name: "ensure definition with replaced int field imports strconv",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  replicas: "replicas=2"  # +operator-builder:field:name=replicas,type=int,default=2,replace="2"
+`,
+			want: []string{"strconv"},
+		},
+		{
+			name: "ensure definition with replaced bool and int64 fields imports strconv",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  enabled: "enabled=true"  # +operator-builder:field:name=enabled,type=bool,default=true,replace="true"
+  size: "size=1024"  # +operator-builder:field:name=size,type=int64,default=1024,replace="1024"
+`,
+			want: []string{"strconv"},
+		},
+		{
+			name: "ensure definition with template marker imports fmt",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  host: test.acme.com  # +operator-builder:template:value="{{ .name }}.acme.com"
+  name: test  # +operator-builder:field:name=name,type=string,default="test"
+`,
+			want: []string{"fmt"},
+		},
+		{
+			name: "ensure definition with template marker on a non-string field imports fmt and strconv",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  url: http://test:8080  # +operator-builder:template:value="http://{{ .name }}:{{ .port }}"
+  name: test  # +operator-builder:field:name=name,type=string,default="test"
+  port: "port=8080"  # +operator-builder:field:name=port,type=int,default=8080,replace="8080"
+`,
+			want: []string{"fmt", "strconv"},
+		},
+		{
+			name: "ensure definition with repeated resource name imports fmt and describes its base name",
+			manifest: `# +operator-builder:repeat:field=hosts
+apiVersion: v1
+kind: Service
+metadata:
+  name: webstore-host-0  # +operator-builder:template:value="webstore-host-{{ .repeat.index }}"
+spec:
+  type: ExternalName
+  externalName: webstore.acme.com  # +operator-builder:template:value="{{ .repeat.item }}"
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: test
+spec:
+  tls:
+  - secretName: test-tls
+    # +operator-builder:field:name=hosts,type=[]string
+    hosts:
+    - webstore.acme.com
+`,
+			want: []string{"fmt"},
+			wantCode: []string{
+				"creates the webstore-host Service resource.",
+				`"name": fmt.Sprintf("webstore-host-%v", repeatIndex)`,
+			},
+		},
+		{
+			name: "ensure definition with an escaped dotted ignored field escapes the policy annotation",
+			manifest: `# +operator-builder:resource:ignoreFields="metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration"
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+`,
+			notWant: []string{"fmt", "strconv"},
+			wantCode: []string{
+				`setPolicyAnnotation(resourceObj, IgnoreFieldsAnnotation, "metadata.annotations.kubectl\\.kubernetes\\.io/last-applied-configuration")`,
+			},
+		},
+		{
+			name: "ensure definition with combined resource markers compares and combines their fields",
+			manifest: `# +operator-builder:resource:field=tier,value="premium",include,operator=or
+# +operator-builder:resource:field=replicas,value=3,compare=gt,include,operator=or
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  tier: premium  # +operator-builder:field:name=tier,type=string,default="premium"
+  replicas: "replicas=3"  # +operator-builder:field:name=replicas,type=int,default=3,replace="3"
+`,
+			want: []string{"strconv"},
+			wantCode: []string{
+				`if parent.Spec.Tier != "premium" && parent.Spec.Replicas <= 3 {`,
+			},
+		},
+		{
+			name: "ensure definition with status marker skips resources which are not found",
+			manifest: `# +operator-builder:status:name=clusterIP,type=string,path="spec.clusterIP"
+apiVersion: v1
+kind: Service
+metadata:
+  name: test
+spec:
+  type: ClusterIP
+`,
+			want: []string{"context", "k8s.io/apimachinery/pkg/api/errors"},
+			wantCode: []string{
+				"if apierrs.IsNotFound(err) {\n\t\t\t\tcontinue\n\t\t\t}\n\n\t\t\treturn err",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			imports, code := scaffoldTestDefinitions(t, tt.manifest)
+
+			for _, want := range tt.want {
+				assert.Contains(t, imports, want)
+			}
+
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, imports, notWant)
+			}
+
+			for _, wantCode := range tt.wantCode {
+				assert.Contains(t, code, wantCode)
+			}
+		})
+	}
+}
+
+// scaffoldTestDefinitions scaffolds the child resource definitions of a standalone workload with
+// a single manifest, ensures that the generated source code is valid and that its imports are
+// exactly those which are referenced, and returns the imports along with the source code.
+func scaffoldTestDefinitions(t *testing.T, manifest string) (imports []string, code string) {
+	t.Helper()
+
+	builder, scaffold, fs := newTestScaffold(t, manifest)
+
+	for _, manifest := range *builder.GetManifests() {
+		definition := &Definition{Builder: builder, Manifest: manifest}
+		require.NoError(t, scaffold.Execute(definition))
+
+		content, err := afero.ReadFile(fs.FS, definition.Path)
+		require.NoError(t, err)
+
+		imports = append(imports, checkTestImports(t, definition.Path, content)...)
+		code += string(content)
+	}
+
+	return imports, code
+}
+
+// testDeepCopyProgram is a program which creates a child resource from its generated source code
+// and deep copies it, which panics for values which may not be stored within an unstructured object.
+const testDeepCopyProgram = `package main
+
+import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+type testSpec struct {
+	NodeSelector map[string]string
+	Args         []string
+	Ports        []int
+	Command      []string
+}
+
+type testParent struct {
+	Spec testSpec
+}
+
+func main() {
+	parent := &testParent{
+		Spec: testSpec{
+			NodeSelector: map[string]string{"app": "test"},
+			Args:         []string{"--test"},
+			Ports:        []int{8080},
+			Command:      []string{"test"},
+		},
+	}
+
+	%s
+	%s
+
+	_ = resourceObj.DeepCopy()
+}
+`
+
+func TestDefinition_deepCopy(t *testing.T) {
+	t.Parallel()
+
+	goCommand, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is required to run the generated source code")
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{
+			name: "ensure child resource with a map field may be deep copied",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  # +operator-builder:field:name=nodeSelector,type=map[string]string
+  nodeSelector:
+    app: test
+`,
+		},
+		{
+			name: "ensure child resource with array fields may be deep copied",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  containers:
+  - name: test
+    # +operator-builder:field:name=args,type=[]string
+    args:
+    - --test
+    # +operator-builder:field:name=ports,type=[]int
+    ports:
+    - 8080
+    # +operator-builder:field:name=command,type=[]string,optional
+    command:
+    - test
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder, _, _ := newTestScaffold(t, tt.manifest)
+
+			manifests := *builder.GetManifests()
+			require.Len(t, manifests, 1)
+
+			childResources := manifests[0].ChildResources
+			require.Len(t, childResources, 1)
+
+			// the program is written within the module so that its dependencies are resolved
+			programPath, err := os.MkdirTemp(".", "deepcopy")
+			require.NoError(t, err)
+
+			defer os.RemoveAll(programPath)
+
+			program := fmt.Sprintf(testDeepCopyProgram, childResources[0].SourceCode, childResources[0].OptionalFieldCode)
+			require.NoError(t, os.WriteFile(filepath.Join(programPath, "main.go"), []byte(program), 0o600))
+
+			output, err := exec.Command(goCommand, "run", "./"+programPath).CombinedOutput()
+			assert.NoError(t, err, string(output))
+		})
+	}
+}
+
+// newTestScaffold processes a standalone workload with a single manifest and returns the workload
+// along with a scaffold which writes to an in-memory filesystem.
+func newTestScaffold(t *testing.T, manifest string) (kinds.WorkloadBuilder, *machinery.Scaffold, machinery.Filesystem) {
+	t.Helper()
+
+	workloadPath := t.TempDir()
+	configPath := filepath.Join(workloadPath, "workload.yaml")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(testDefinitionWorkload), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(workloadPath, "resources.yaml"), []byte(manifest), 0o600))
+
+	processor, err := config.Parse(configPath)
+	require.NoError(t, err)
+	require.NoError(t, subcommand.CreateAPI(processor, 0))
+
+	cfg := cfgv3.New()
+	require.NoError(t, cfg.SetRepository("github.com/acme/test-operator"))
+
+	fs := machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+	scaffold := machinery.NewScaffold(fs,
+		machinery.WithConfig(cfg),
+		machinery.WithBoilerplate(""),
+		machinery.WithResource(&resource.Resource{
+			GVK: resource.GVK{
+				Domain:  processor.Workload.GetDomain(),
+				Group:   processor.Workload.GetAPIGroup(),
+				Version: processor.Workload.GetAPIVersion(),
+				Kind:    processor.Workload.GetAPIKind(),
+			},
+			Path: "github.com/acme/test-operator/apis/apps/v1alpha1",
+		}),
+	)
+
+	return processor.Workload, scaffold, fs
+}
+
+// checkTestImports parses the generated source code, which fails for invalid source code, and
+// ensures that every package which is referenced is imported and that every import is used, as
+// either would fail to compile.
+func checkTestImports(t *testing.T, filename string, content []byte) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), filename, content, 0)
+	require.NoError(t, err, string(content))
+
+	imported := map[string]string{}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		require.NoError(t, err)
+
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imported[name] = path
+	}
+
+	used := map[string]bool{}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		// a package is referenced by an identifier which is not declared within the file
+		if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+			_, found := imported[ident.Name]
+			assert.True(t, found, "package %s is referenced but not imported by %s", ident.Name, filename)
+
+			used[ident.Name] = true
+		}
+
+		return true
+	})
+
+	imports := []string{}
+
+	for name, path := range imported {
+		assert.True(t, used[name], "package %s is imported but not referenced by %s", path, filename)
+
+		imports = append(imports, path)
+	}
+
+	return imports
+}
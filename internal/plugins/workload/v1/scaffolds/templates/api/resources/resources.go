@@ -11,6 +11,7 @@ import (
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/samples"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
 )
 
 var _ machinery.Template = &Resources{}
@@ -26,15 +27,31 @@ type Resources struct {
 	Builder kinds.WorkloadBuilder
 
 	// template fields
-	SpecFields      *kinds.APIFields
-	IsClusterScoped bool
-	CreateFuncNames []string
-	InitFuncNames   []string
+	SpecFields          *kinds.APIFields
+	IsClusterScoped     bool
+	CreateFuncNames     []string
+	CreateFuncWaves     [][]string
+	ReadyFuncWaves      [][]string
+	HasWaves            bool
+	HasPolicies         bool
+	HasDeletionPolicies bool
+	InitFuncNames       []string
+	StatusFuncNames     []string
+	ReadyFuncNames      []string
+	SensitiveFields     []*markers.SensitiveField
 }
 
 func (f *Resources) SetTemplateDefaults() error {
 	// set template fields
 	f.CreateFuncNames, f.InitFuncNames = f.Builder.GetManifests().FuncNames()
+	f.CreateFuncWaves = f.Builder.GetManifests().CreateFuncWaves()
+	f.ReadyFuncWaves = f.Builder.GetManifests().ReadyFuncWaves()
+	f.HasWaves = len(f.CreateFuncWaves) > 1
+	f.HasPolicies = f.Builder.GetManifests().HasPolicies()
+	f.HasDeletionPolicies = f.Builder.GetManifests().HasDeletionPolicies()
+	f.StatusFuncNames = f.Builder.GetManifests().StatusFuncNames()
+	f.ReadyFuncNames = f.Builder.GetManifests().ReadyFuncNames()
+	f.SensitiveFields = f.Builder.GetSensitiveFields()
 	f.SpecFields = f.Builder.GetAPISpecFields()
 	f.IsClusterScoped = f.Builder.IsClusterScoped()
 
@@ -62,9 +79,16 @@ const resourcesTemplate = `{{ .Boilerplate }}
 package {{ .Builder.GetPackageName }}
 
 import (
-	{{ if ne .Builder.GetRootCommand.Name "" }}"fmt"{{ end }}
+	{{ if or (ne (len .StatusFuncNames) 0) (ne (len .ReadyFuncNames) 0) (ne (len .SensitiveFields) 0) .HasWaves .HasPolicies }}"context"{{ end }}
+	{{ if ne (len .StatusFuncNames) 0 }}"encoding/json"{{ end }}
+	{{ if or (ne .Builder.GetRootCommand.Name "") (ne (len .SensitiveFields) 0) (ne (len .ReadyFuncNames) 0) .HasWaves }}"fmt"{{ end }}
+	{{ if or (ne (len .SensitiveFields) 0) .HasPolicies (ne (len .ReadyFuncNames) 0) .HasWaves }}"strings"{{ end }}
 
 	{{ if ne .Builder.GetRootCommand.Name "" }}"sigs.k8s.io/yaml"{{ end }}
+	{{ if ne (len .SensitiveFields) 0 }}corev1 "k8s.io/api/core/v1"{{ end }}
+	{{ if or (ne (len .SensitiveFields) 0) .HasWaves .HasPolicies }}apierrs "k8s.io/apimachinery/pkg/api/errors"{{ end }}
+	{{ if or .HasDeletionPolicies (ne (len .ReadyFuncNames) 0) .HasWaves }}metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"{{ end }}
+	{{ if or (ne (len .StatusFuncNames) 0) (ne (len .ReadyFuncNames) 0) (ne (len .SensitiveFields) 0) .HasWaves .HasPolicies }}"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"{{ end }}
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
@@ -194,6 +218,726 @@ var InitFuncs = []func(
 	{{ end }}
 }
 
+{{ if .HasWaves -}}
+// CreateFuncWaves is an array of the CreateFuncs grouped by the wave in which their child resources are
+// created.  The child resources of a wave are only created once the child resources of all of the previous
+// waves are ready in the cluster.
+var CreateFuncWaves = [][]func(
+	*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) ([]client.Object, error){
+	{{- range .CreateFuncWaves }}
+	{
+		{{- range . }}
+		{{ . }},
+		{{- end }}
+	},
+	{{- end }}
+}
+
+{{ if ne (len .ReadyFuncNames) 0 -}}
+// ReadyFuncWaves is an array of the ReadyFuncs grouped in the same way as the CreateFuncWaves.  The ready
+// function of a child resource, if it has one, checks the readiness of its child resources before the next
+// wave is created.
+var ReadyFuncWaves = [][]func(
+	context.Context,
+	client.Reader,
+	*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) ([]metav1.Condition, error){
+	{{- range .ReadyFuncWaves }}
+	{
+		{{- range . }}
+		{{ if eq . "" }}nil{{ else }}{{ . }}{{ end }},
+		{{- end }}
+	},
+	{{- end }}
+}
+
+{{ end -}}
+// GenerateReadyWaves returns the child resources of each wave, in order, up to and including the first wave
+// whose child resources are not yet ready in the cluster.  It also returns whether the child resources of all
+// of the waves are ready.
+{{ if .Builder.IsComponent -}}
+func GenerateReadyWaves(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj {{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	collectionObj {{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+) ([]client.Object, bool, error) {
+{{ else if .Builder.IsCollection -}}
+func GenerateReadyWaves(
+	ctx context.Context,
+	reader client.Reader,
+	collectionObj {{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+) ([]client.Object, bool, error) {
+{{ else -}}
+func GenerateReadyWaves(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj {{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+) ([]client.Object, bool, error) {
+{{ end -}}
+	resourceObjects := []client.Object{}
+
+	for {{ if ne (len .ReadyFuncNames) 0 }}i{{ else }}_{{ end }}, wave := range CreateFuncWaves {
+		waveReady := true
+
+		for {{ if ne (len .ReadyFuncNames) 0 }}j{{ else }}_{{ end }}, f := range wave {
+			{{ if .Builder.IsComponent -}}
+			resources, err := f(&workloadObj, &collectionObj)
+			{{ else if .Builder.IsCollection -}}
+			resources, err := f(&collectionObj)
+			{{ else -}}
+			resources, err := f(&workloadObj)
+			{{ end -}}
+			if err != nil {
+				return nil, false, err
+			}
+
+			resourceObjects = append(resourceObjects, resources...)
+
+			// the readiness of the remaining child resources of a wave is not checked once one is not ready
+			if !waveReady {
+				continue
+			}
+			{{- if ne (len .ReadyFuncNames) 0 }}
+
+			// child resources with ready markers are checked by their ready function rather than by default
+			if readyFunc := ReadyFuncWaves[i][j]; readyFunc != nil {
+				{{ if .Builder.IsComponent -}}
+				conditions, err := readyFunc(ctx, reader, &workloadObj, &collectionObj)
+				{{ else if .Builder.IsCollection -}}
+				conditions, err := readyFunc(ctx, reader, &collectionObj)
+				{{ else -}}
+				conditions, err := readyFunc(ctx, reader, &workloadObj)
+				{{ end -}}
+				if err != nil {
+					return nil, false, err
+				}
+
+				for _, condition := range conditions {
+					waveReady = waveReady && condition.Status == metav1.ConditionTrue
+				}
+
+				continue
+			}
+			{{- end }}
+
+			if waveReady, err = resourcesReady(ctx, reader, resources); err != nil {
+				return nil, false, err
+			}
+		}
+
+		// the child resources of the next wave are not created until this wave is ready
+		if !waveReady {
+			return resourceObjects, false, nil
+		}
+	}
+
+	return resourceObjects, true, nil
+}
+
+// resourcesReady returns whether each of the child resources exists and is ready in the cluster.
+func resourcesReady(ctx context.Context, reader client.Reader, resourceObjs []client.Object) (bool, error) {
+	for _, resourceObj := range resourceObjs {
+		liveObj := &unstructured.Unstructured{}
+		liveObj.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(resourceObj), liveObj); err != nil {
+			if apierrs.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		if checkReady(liveObj, defaultReadyChecks(liveObj)...).Status != metav1.ConditionTrue {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// defaultReadyChecks returns the checks of a live child resource which has no ready markers.  Child resources
+// which report a Ready, Available or Established condition are ready once the condition is true, while child
+// resources which request replicas are ready once all of their replicas are ready.  All other child resources
+// are ready once they exist.
+func defaultReadyChecks(object *unstructured.Unstructured) []readyCheck {
+	checks := []readyCheck{}
+
+	for _, conditionType := range []string{"Ready", "Available", "Established"} {
+		if _, found := getCondition(object, conditionType); found {
+			checks = append(checks, readyCondition(conditionType))
+		}
+	}
+
+	if _, found, _ := unstructured.NestedInt64(object.Object, "spec", "replicas"); found {
+		checks = append(checks, readyReplicas())
+	}
+
+	return checks
+}
+
+{{ end -}}
+
+{{ if .HasPolicies -}}
+// annotations which request the policies with which the child resources are reconciled.  The annotations are
+// set by the CreateFuncs and may also be set, or removed, by the mutate functions.
+const (
+	// CreateOnlyAnnotation requests that a child resource is created once and never updated.
+	CreateOnlyAnnotation = "operator-builder.io/create-only"
+
+	// IgnoreFieldsAnnotation requests that the comma-separated paths of the fields of a child resource
+	// (e.g. spec.replicas) are not updated once the child resource exists.
+	IgnoreFieldsAnnotation = "operator-builder.io/ignore-fields"
+
+	// DeletionPolicyAnnotation requests what happens to a child resource when the custom resource is deleted.
+	// Child resources are deleted along with the custom resource unless they are orphaned or retained.
+	DeletionPolicyAnnotation = "operator-builder.io/deletion-policy"
+
+	// RetainedFromAnnotation records the custom resource from which a retained child resource was released.
+	RetainedFromAnnotation = "operator-builder.io/retained-from"
+)
+
+// deletion policies which may be requested by the DeletionPolicyAnnotation of a child resource.
+const (
+	DeletionPolicyDelete = "delete"
+	DeletionPolicyOrphan = "orphan"
+	DeletionPolicyRetain = "retain"
+)
+
+// ApplyPolicies applies the policies requested by the annotations of the child resources against the live
+// child resources in the cluster.  Child resources which are only created once are omitted once they exist,
+// while the ignored fields of the other child resources are replaced with those of the live child resources
+// so that changes made by others are not overwritten.
+func ApplyPolicies(ctx context.Context, reader client.Reader, resourceObjs []client.Object) ([]client.Object, error) {
+	policyObjs := []client.Object{}
+
+	for _, resourceObj := range resourceObjs {
+		annotations := resourceObj.GetAnnotations()
+
+		createOnly := annotations[CreateOnlyAnnotation] == "true"
+		ignoreFields := annotations[IgnoreFieldsAnnotation]
+
+		object, ok := resourceObj.(*unstructured.Unstructured)
+		if !ok || (!createOnly && ignoreFields == "") {
+			policyObjs = append(policyObjs, resourceObj)
+
+			continue
+		}
+
+		liveObj := &unstructured.Unstructured{}
+		liveObj.SetGroupVersionKind(object.GroupVersionKind())
+
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(object), liveObj); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+
+			policyObjs = append(policyObjs, object)
+
+			continue
+		}
+
+		if createOnly {
+			continue
+		}
+
+		for _, field := range strings.Split(ignoreFields, ",") {
+			path := splitFieldPath(strings.TrimSpace(field))
+
+			value, found, err := unstructured.NestedFieldCopy(liveObj.Object, path...)
+			if err != nil {
+				return nil, err
+			}
+
+			// a field which was removed by others is not restored
+			if !found {
+				unstructured.RemoveNestedField(object.Object, path...)
+
+				continue
+			}
+
+			if err := unstructured.SetNestedField(object.Object, value, path...); err != nil {
+				return nil, err
+			}
+		}
+
+		policyObjs = append(policyObjs, object)
+	}
+
+	return policyObjs, nil
+}
+
+// splitFieldPath splits the path of an ignored field into its keys.  The keys are separated by dots, while a dot
+// within a key is escaped by a backslash (e.g. metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration).
+func splitFieldPath(field string) []string {
+	path := []string{}
+	key := strings.Builder{}
+
+	for i := 0; i < len(field); i++ {
+		switch {
+		case field[i] == '\\' && i+1 < len(field) && field[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case field[i] == '.':
+			path = append(path, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(field[i])
+		}
+	}
+
+	return append(path, key.String())
+}
+
+{{ if .HasDeletionPolicies -}}
+// ReleaseResources releases the live child resources which are orphaned or retained by their deletion policy,
+// by removing their owner references to the custom resource, so that they are not garbage collected when the
+// custom resource is deleted.  Retained child resources are also annotated with the custom resource from
+// which they were retained.
+func ReleaseResources(ctx context.Context, c client.Client, parent client.Object, resourceObjs []client.Object) error {
+	for _, resourceObj := range resourceObjs {
+		policy := resourceObj.GetAnnotations()[DeletionPolicyAnnotation]
+		if policy != DeletionPolicyOrphan && policy != DeletionPolicyRetain {
+			continue
+		}
+
+		liveObj := &unstructured.Unstructured{}
+		liveObj.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+		if err := c.Get(ctx, client.ObjectKeyFromObject(resourceObj), liveObj); err != nil {
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		ownerReferences := []metav1.OwnerReference{}
+
+		for _, ownerReference := range liveObj.GetOwnerReferences() {
+			if ownerReference.UID != parent.GetUID() {
+				ownerReferences = append(ownerReferences, ownerReference)
+			}
+		}
+
+		liveObj.SetOwnerReferences(ownerReferences)
+
+		if policy == DeletionPolicyRetain {
+			annotations := liveObj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			annotations[RetainedFromAnnotation] = "{{ .Resource.Kind }}/" + parent.GetName()
+
+			liveObj.SetAnnotations(annotations)
+		}
+
+		if err := c.Update(ctx, liveObj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+{{ end -}}
+// setPolicyAnnotation sets an annotation which requests a policy for a child resource.
+func setPolicyAnnotation(object *unstructured.Unstructured, key, value string) {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[key] = value
+
+	object.SetAnnotations(annotations)
+}
+
+{{ end -}}
+{{ if ne (len .StatusFuncNames) 0 -}}
+// StatusFuncs is an array of functions that are called to set the status fields of the custom resource
+// which are projected from the live child resources in the cluster.
+var StatusFuncs = []func(
+	context.Context,
+	client.Reader,
+	*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) error {
+	{{ range .StatusFuncNames }}
+		{{- . -}},
+	{{ end }}
+}
+
+// SetStatus sets the status fields of the custom resource which are projected from the live child
+// resources in the cluster.  Child resources which do not yet exist are skipped.
+{{ if .Builder.IsComponent -}}
+func SetStatus(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	collectionObj *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+) error {
+{{ else -}}
+func SetStatus(ctx context.Context, reader client.Reader, workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) error {
+{{ end -}}
+	for _, f := range StatusFuncs {
+		{{ if .Builder.IsComponent -}}
+		if err := f(ctx, reader, workloadObj, collectionObj); err != nil {
+		{{ else -}}
+		if err := f(ctx, reader, workloadObj); err != nil {
+		{{ end -}}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setStatusField sets a status field from the value found at a path within a live child resource.  The
+// status field is left unchanged if the path does not exist within the child resource.
+func setStatusField(object *unstructured.Unstructured, field interface{}, path ...string) error {
+	value, found, err := unstructured.NestedFieldNoCopy(object.Object, path...)
+	if err != nil || !found {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, field)
+}
+
+{{ end -}}
+{{ if ne (len .ReadyFuncNames) 0 -}}
+// ReadyFuncs is an array of functions that are called to check the readiness of the live child resources
+// in the cluster, as requested by their ready markers.
+var ReadyFuncs = []func(
+	context.Context,
+	client.Reader,
+	*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{ if $.Builder.IsComponent -}}
+	*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{ end -}}
+) ([]metav1.Condition, error) {
+	{{ range .ReadyFuncNames }}
+		{{- . -}},
+	{{ end }}
+}
+
+// CheckReady checks the readiness of the live child resources in the cluster, as requested by their ready
+// markers, and sets a ready condition for each of them within the status of the custom resource.  It returns
+// whether all of the checked child resources are ready.
+{{ if .Builder.IsComponent -}}
+func CheckReady(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	collectionObj *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+) (bool, error) {
+{{ else -}}
+func CheckReady(ctx context.Context, reader client.Reader, workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (bool, error) {
+{{ end -}}
+	ready := true
+	readyConditions := []metav1.Condition{}
+
+	for _, f := range ReadyFuncs {
+		{{ if .Builder.IsComponent -}}
+		conditions, err := f(ctx, reader, workloadObj, collectionObj)
+		{{ else -}}
+		conditions, err := f(ctx, reader, workloadObj)
+		{{ end -}}
+		if err != nil {
+			return false, err
+		}
+
+		for _, condition := range conditions {
+			condition.ObservedGeneration = workloadObj.GetGeneration()
+
+			// the transition time of a condition is only updated when its status changes
+			for _, existing := range workloadObj.Status.ReadyConditions {
+				if existing.Type == condition.Type && existing.Status == condition.Status {
+					condition.LastTransitionTime = existing.LastTransitionTime
+				}
+			}
+
+			ready = ready && condition.Status == metav1.ConditionTrue
+			readyConditions = append(readyConditions, condition)
+		}
+	}
+
+	// the conditions of child resources which are no longer checked are removed
+	workloadObj.Status.ReadyConditions = readyConditions
+
+	return ready, nil
+}
+
+{{ end -}}
+{{ if or .HasWaves (ne (len .ReadyFuncNames) 0) -}}
+// readyCheck checks a live child resource and returns whether it is ready, along with a message which
+// describes why it is not.
+type readyCheck func(object *unstructured.Unstructured) (bool, string)
+
+// checkReady returns the ready condition of a live child resource, which is true once all of its checks pass.
+func checkReady(object *unstructured.Unstructured, checks ...readyCheck) metav1.Condition {
+	for _, check := range checks {
+		if ready, message := check(object); !ready {
+			return newReadyCondition(object, metav1.ConditionFalse, "NotReady", message)
+		}
+	}
+
+	return newReadyCondition(object, metav1.ConditionTrue, "Ready", "resource is ready")
+}
+
+// newReadyCondition returns a ready condition for a child resource.  The type of the condition identifies the
+// child resource by its kind and name (e.g. deployment/my-app).
+func newReadyCondition(object client.Object, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               strings.ToLower(object.GetObjectKind().GroupVersionKind().Kind) + "/" + object.GetName(),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// readyCondition checks that a live child resource reports a condition of the given type with a true status.
+func readyCondition(conditionType string) readyCheck {
+	return func(object *unstructured.Unstructured) (bool, string) {
+		condition, found := getCondition(object, conditionType)
+		if !found {
+			return false, fmt.Sprintf("condition %%s is not reported", conditionType)
+		}
+
+		return condition["status"] == "True", fmt.Sprintf("condition %%s has status %%v", conditionType, condition["status"])
+	}
+}
+
+// getCondition returns the fields of the condition of the given type which is reported by a live child resource.
+func getCondition(object *unstructured.Unstructured, conditionType string) (map[string]interface{}, bool) {
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if ok && fields["type"] == conditionType {
+			return fields, true
+		}
+	}
+
+	return nil, false
+}
+
+// readyReplicas checks that all of the desired replicas of a live child resource are ready.  A single replica
+// is desired when the child resource does not request a number of replicas.
+func readyReplicas() readyCheck {
+	return func(object *unstructured.Unstructured) (bool, string) {
+		replicas, found, _ := unstructured.NestedInt64(object.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+
+		readyReplicas, _, _ := unstructured.NestedInt64(object.Object, "status", "readyReplicas")
+
+		return readyReplicas >= replicas, fmt.Sprintf("%%d of %%d replicas are ready", readyReplicas, replicas)
+	}
+}
+
+// readyScheduled checks that a pod is ready on each of the nodes on which a live child resource, such as a
+// daemon set, has scheduled one.
+func readyScheduled() readyCheck {
+	return func(object *unstructured.Unstructured) (bool, string) {
+		scheduled, found, _ := unstructured.NestedInt64(object.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(object.Object, "status", "numberReady")
+
+		return found && ready >= scheduled, fmt.Sprintf("%%d of %%d scheduled pods are ready", ready, scheduled)
+	}
+}
+
+// readyField checks that a field is set within a live child resource and, unless the value is nil, that it
+// has the given value.
+func readyField(value interface{}, path ...string) readyCheck {
+	return func(object *unstructured.Unstructured) (bool, string) {
+		field := strings.Join(path, ".")
+
+		actual, found, err := unstructured.NestedFieldNoCopy(object.Object, path...)
+		if err != nil || !found {
+			return false, fmt.Sprintf("field %%s is not set", field)
+		}
+
+		if value != nil && fmt.Sprint(actual) != fmt.Sprint(value) {
+			return false, fmt.Sprintf("field %%s has value %%v rather than %%v", field, actual, value)
+		}
+
+		return true, ""
+	}
+}
+
+{{ end -}}
+{{ if ne (len .SensitiveFields) 0 -}}
+// placeholders of the sensitive fields which are rendered within the child resources in place of the
+// values which are held by secrets.
+const (
+	{{- range .SensitiveFields }}
+	{{ .GetVariableName }} = "{{ .GetPlaceholder }}"
+	{{- end }}
+)
+
+// ResolveSensitiveFields replaces the placeholders of the sensitive fields within the child secrets
+// with the values found within the secrets which are referenced by the custom resource.  Secrets are
+// read from the namespace of the custom resource which references them.
+{{ if .Builder.IsComponent -}}
+func ResolveSensitiveFields(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	collectionObj *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	resourceObjs []client.Object,
+) error {
+{{ else if .Builder.IsCollection -}}
+func ResolveSensitiveFields(
+	ctx context.Context,
+	reader client.Reader,
+	collectionObj *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	resourceObjs []client.Object,
+) error {
+{{ else -}}
+func ResolveSensitiveFields(
+	ctx context.Context,
+	reader client.Reader,
+	workloadObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	resourceObjs []client.Object,
+) error {
+{{ end -}}
+	resolver := &sensitiveFieldResolver{
+		ctx:    ctx,
+		reader: reader,
+		values: map[string]string{},
+		fields: map[string]sensitiveField{
+			{{- range .SensitiveFields }}
+			{{- if or ($.Builder.IsCollection) (.Collection) }}
+			{{ .GetVariableName }}: {namespace: collectionObj.Namespace, selector: collectionObj.Spec.{{ .GetFieldName }}},
+			{{- else }}
+			{{ .GetVariableName }}: {namespace: workloadObj.Namespace, selector: workloadObj.Spec.{{ .GetFieldName }}},
+			{{- end }}
+			{{- end }}
+		},
+	}
+
+	for _, resourceObj := range resourceObjs {
+		// sensitive fields are only resolved within secrets so that their values are never held in plain text
+		object, ok := resourceObj.(*unstructured.Unstructured)
+		if !ok || object.GroupVersionKind().Group != "" || object.GetKind() != "Secret" {
+			continue
+		}
+
+		// the contents of the object are resolved in place
+		if _, err := resolver.resolve(object.Object); err != nil {
+			return fmt.Errorf("unable to resolve sensitive fields for %%s %%s, %%w", object.GetKind(), object.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// sensitiveField is a reference to the key of a secret which holds the value of a sensitive field.
+type sensitiveField struct {
+	namespace string
+	selector  corev1.SecretKeySelector
+}
+
+// sensitiveFieldResolver resolves the placeholders of the sensitive fields within a child resource.  Each
+// referenced secret key is only read once.
+type sensitiveFieldResolver struct {
+	ctx    context.Context
+	reader client.Reader
+	fields map[string]sensitiveField
+	values map[string]string
+}
+
+// resolve replaces the placeholders of the sensitive fields within any string values found within a value.
+func (resolver *sensitiveFieldResolver) resolve(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key := range typedValue {
+			resolved, err := resolver.resolve(typedValue[key])
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = resolved
+		}
+	case []interface{}:
+		for i := range typedValue {
+			resolved, err := resolver.resolve(typedValue[i])
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[i] = resolved
+		}
+	case string:
+		for placeholder, field := range resolver.fields {
+			if !strings.Contains(typedValue, placeholder) {
+				continue
+			}
+
+			secretValue, err := resolver.getValue(placeholder, field)
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue = strings.ReplaceAll(typedValue, placeholder, secretValue)
+		}
+
+		return typedValue, nil
+	}
+
+	return value, nil
+}
+
+// getValue returns the value of a sensitive field from the secret which it references.  An empty value
+// is returned for an optional reference to a secret, or a secret key, which does not exist.
+func (resolver *sensitiveFieldResolver) getValue(placeholder string, field sensitiveField) (string, error) {
+	if value, found := resolver.values[placeholder]; found {
+		return value, nil
+	}
+
+	optional := field.selector.Optional != nil && *field.selector.Optional
+
+	secret := &corev1.Secret{}
+	if err := resolver.reader.Get(
+		resolver.ctx,
+		client.ObjectKey{Namespace: field.namespace, Name: field.selector.Name},
+		secret,
+	); err != nil {
+		if !optional || !apierrs.IsNotFound(err) {
+			return "", fmt.Errorf("unable to get secret %%s/%%s, %%w", field.namespace, field.selector.Name, err)
+		}
+	}
+
+	value, found := secret.Data[field.selector.Key]
+	if !found && !optional {
+		return "", fmt.Errorf("key %%s not found in secret %%s/%%s", field.selector.Key, field.namespace, field.selector.Name)
+	}
+
+	resolver.values[placeholder] = string(value)
+
+	return string(value), nil
+}
+
+{{ end -}}
 {{ if $.Builder.IsComponent -}}
 func ConvertWorkload(component, collection workload.Workload) (
 	*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
@@ -0,0 +1,108 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		manifest    string
+		wantCode    []string
+		notWantCode []string
+	}{
+		{
+			name: "ensure waves without ready markers are checked by default",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+# +operator-builder:resource:wave=1
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+`,
+			wantCode: []string{
+				"waveReady, err = resourcesReady(ctx, reader, resources)",
+				"checkReady(liveObj, defaultReadyChecks(liveObj)...)",
+			},
+			notWantCode: []string{
+				"ReadyFuncWaves",
+			},
+		},
+		{
+			name: "ensure waves with ready markers are checked by their ready functions",
+			manifest: `# +operator-builder:ready:preset=deployment
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+---
+# +operator-builder:resource:wave=1
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: third
+`,
+			wantCode: []string{
+				"CheckReadyForDeploymentFirst,\n\t\tnil,\n\t},\n\t{\n\t\tnil,\n\t},",
+				"if readyFunc := ReadyFuncWaves[i][j]; readyFunc != nil {",
+				"waveReady, err = resourcesReady(ctx, reader, resources)",
+			},
+		},
+		{
+			name: "ensure ignored fields are split on unescaped dots",
+			manifest: `# +operator-builder:resource:ignoreFields="metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration"
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+`,
+			wantCode: []string{
+				"path := splitFieldPath(strings.TrimSpace(field))",
+				"func splitFieldPath(field string) []string {",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder, scaffold, fs := newTestScaffold(t, tt.manifest)
+
+			resources := &Resources{Builder: builder}
+			require.NoError(t, scaffold.Execute(resources))
+
+			content, err := afero.ReadFile(fs.FS, resources.Path)
+			require.NoError(t, err)
+
+			checkTestImports(t, resources.Path, content)
+
+			for _, wantCode := range tt.wantCode {
+				assert.Contains(t, string(content), wantCode)
+			}
+
+			for _, notWantCode := range tt.notWantCode {
+				assert.NotContains(t, string(content), notWantCode)
+			}
+		})
+	}
+}
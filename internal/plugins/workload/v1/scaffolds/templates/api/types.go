@@ -13,6 +13,7 @@ import (
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
 )
 
 var _ machinery.Template = &Types{}
@@ -26,6 +27,11 @@ type Types struct {
 
 	// input fields
 	Builder kinds.WorkloadBuilder
+
+	// template fields
+	Imports        map[string]string
+	PrintColumns   []*markers.PrintColumn
+	HasReadyChecks bool
 }
 
 // SetTemplateDefaults implements file.Template.
@@ -37,6 +43,23 @@ func (f *Types) SetTemplateDefaults() error {
 		fmt.Sprintf("%s_types.go", strings.ToLower(f.Resource.Kind)),
 	)
 
+	// set the imports needed by the types of both the spec and status fields
+	f.Imports = f.Builder.GetAPISpecFields().GetImports()
+
+	for _, statusMarker := range f.Builder.GetStatusMarkers() {
+		if importPath := statusMarker.Type.ImportPath(); importPath != "" {
+			f.Imports[statusMarker.Type.ImportAlias()] = importPath
+		}
+	}
+
+	f.HasReadyChecks = len(f.Builder.GetManifests().ReadyFuncNames()) > 0
+
+	// set the print columns, ensuring the age of the custom resource is still displayed as it
+	// is only displayed by default when no print columns are defined
+	if printColumns := f.Builder.GetPrintColumns(); len(printColumns) > 0 {
+		f.PrintColumns = append(printColumns, markers.NewAgePrintColumn())
+	}
+
 	f.TemplateBody = typesTemplate
 	f.IfExistsAction = machinery.OverwriteFile
 
@@ -58,6 +81,11 @@ import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	{{- range $alias, $path := .Imports }}
+	{{- if ne $alias "metav1" }}
+	{{ $alias }} "{{ $path }}"
+	{{- end }}
+	{{- end }}
 
 	{{- $Repo := .Repo }}{{- $Added := "" }}{{- range .Builder.GetDependencies }}
 	{{- if ne .Spec.API.Group $.Resource.Group }}
@@ -85,13 +113,28 @@ type {{ .Resource.Kind }}Status struct {
 	DependenciesSatisfied bool                       ` + "`" + `json:"dependenciesSatisfied,omitempty"` + "`" + `
 	Conditions            []*status.PhaseCondition   ` + "`" + `json:"conditions,omitempty"` + "`" + `
 	Resources             []*status.ChildResource    ` + "`" + `json:"resources,omitempty"` + "`" + `
+	{{- if .HasReadyChecks }}
+
+	// ReadyConditions are the ready conditions of the child resources which are checked by ready markers.
+	ReadyConditions []metav1.Condition ` + "`" + `json:"readyConditions,omitempty"` + "`" + `
+	{{- end }}
+	{{- range .Builder.GetStatusMarkers }}
+
+	{{ range .GetComments -}}
+	// {{ . }}
+	{{ end -}}
+	{{ .GetFieldName }} {{ .Type }} ` + "`" + `json:"{{ .GetName }},omitempty"` + "`" + `
+	{{- end }}
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 {{- if .Builder.IsClusterScoped }}
 // +kubebuilder:resource:scope=Cluster
-{{ end }}
+{{- end }}
+{{- range .PrintColumns }}
+{{ .ToMarker }}
+{{- end }}
 
 // {{ .Resource.Kind }} is the Schema for the {{ .Resource.Plural }} API.
 type {{ .Resource.Kind }} struct {
@@ -26,9 +26,13 @@ type Controller struct {
 	Builder kinds.WorkloadBuilder
 
 	// template fields
-	BaseImports     []string
-	OtherImports    []string
-	InternalImports []string
+	BaseImports         []string
+	OtherImports        []string
+	InternalImports     []string
+	HasWaves            bool
+	HasPolicies         bool
+	HasDeletionPolicies bool
+	HasReadyChecks      bool
 }
 
 func (f *Controller) SetTemplateDefaults() error {
@@ -41,6 +45,11 @@ func (f *Controller) SetTemplateDefaults() error {
 	f.TemplateBody = controllerTemplate
 	f.IfExistsAction = machinery.OverwriteFile
 
+	f.HasWaves = len(f.Builder.GetManifests().CreateFuncWaves()) > 1
+	f.HasPolicies = f.Builder.GetManifests().HasPolicies()
+	f.HasDeletionPolicies = f.Builder.GetManifests().HasDeletionPolicies()
+	f.HasReadyChecks = len(f.Builder.GetManifests().ReadyFuncNames()) > 0
+
 	f.setBaseImports()
 	f.setOtherImports()
 	f.setInternalImports()
@@ -189,6 +198,17 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, nil
 	}
 
+	{{- if .HasDeletionPolicies }}
+
+	// release the child resources which are orphaned or retained by their deletion policy before the
+	// delete hooks allow the workload, and the child resources which it owns, to be deleted
+	if !req.Workload.GetDeletionTimestamp().IsZero() {
+		if err := r.ReleaseResources(req); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	{{- end }}
+
 	if err := phases.RegisterDeleteHooks(r, req); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -349,11 +369,28 @@ func (r *{{ .Resource.Kind }}Reconciler) GetResources(req *workload.Request) ([]
 		return nil, err
 	}
 
+	{{- if .HasWaves }}
+
+	// create resources in memory for each wave up to the first wave which is not yet ready
+	resources, _, err := {{ .Builder.GetPackageName }}.GenerateReadyWaves(req.Context, r, *component{{ if .Builder.IsComponent }}, *collection{{ end }})
+	if err != nil {
+		return nil, err
+	}
+	{{- else }}
+
 	// create resources in memory
 	resources, err := {{ .Builder.GetPackageName }}.Generate(*component{{ if .Builder.IsComponent }}, *collection{{ end }})
 	if err != nil {
 		return nil, err
 	}
+	{{- end }}
+	{{- if ne (len .Builder.GetSensitiveFields) 0 }}
+
+	// resolve the values of the sensitive fields from the secrets referenced by the workload
+	if err := {{ .Builder.GetPackageName }}.ResolveSensitiveFields(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }}, resources); err != nil {
+		return nil, err
+	}
+	{{- end }}
 
 	// run through the mutation functions to mutate the resources
 	for _, resource := range resources {
@@ -368,13 +405,55 @@ func (r *{{ .Resource.Kind }}Reconciler) GetResources(req *workload.Request) ([]
 
 		resourceObjects = append(resourceObjects, mutatedResources...)
 	}
+	{{- if .HasPolicies }}
+
+	// apply the policies of the resources, including those returned by the mutation functions,
+	// against the live resources in the cluster
+	return {{ .Builder.GetPackageName }}.ApplyPolicies(req.Context, r, resourceObjects)
+	{{- else }}
 
 	return resourceObjects, nil
+	{{- end }}
 {{- else -}}
 	return []client.Object{}, nil
 {{ end -}}
 }
 
+{{ if .HasDeletionPolicies -}}
+// ReleaseResources releases the child resources which are orphaned or retained by their deletion policy so that
+// they are not garbage collected along with the workload.
+func (r *{{ .Resource.Kind }}Reconciler) ReleaseResources(req *workload.Request) error {
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return err
+	}
+
+	// create the resources of every wave in memory, as any of them may exist
+	resources, err := {{ .Builder.GetPackageName }}.Generate(*component{{ if .Builder.IsComponent }}, *collection{{ end }})
+	if err != nil {
+		return err
+	}
+
+	// run through the mutation functions, which may change the deletion policies of the resources
+	resourceObjects := []client.Object{}
+
+	for _, resource := range resources {
+		mutatedResources, skip, err := r.Mutate(req, resource)
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			continue
+		}
+
+		resourceObjects = append(resourceObjects, mutatedResources...)
+	}
+
+	return {{ .Builder.GetPackageName }}.ReleaseResources(req.Context, r, req.Workload, resourceObjects)
+}
+
+{{ end -}}
 // GetEventRecorder returns the event recorder for writing kubernetes events.
 func (r *{{ .Resource.Kind }}Reconciler) GetEventRecorder() record.EventRecorder {
 	return r.Events
@@ -412,6 +491,38 @@ func (r *{{ .Resource.Kind }}Reconciler) SetWatch(watch client.Object) {
 
 // CheckReady will return whether a component is ready.
 func (r *{{ .Resource.Kind }}Reconciler) CheckReady(req *workload.Request) (bool, error) {
+	{{- if or (ne (len .Builder.GetStatusMarkers) 0) .HasWaves .HasReadyChecks }}
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return false, err
+	}
+
+	{{ end }}
+	{{- if .HasWaves }}
+	// the workload is not ready until the child resources of every wave have been created and are
+	// ready, as each wave is only created once the previous wave is ready
+	_, ready, err := {{ .Builder.GetPackageName }}.GenerateReadyWaves(req.Context, r, *component{{ if .Builder.IsComponent }}, *collection{{ end }})
+	if err != nil || !ready {
+		return false, err
+	}
+
+	{{ end }}
+	{{- if ne (len .Builder.GetStatusMarkers) 0 }}
+	// project the fields of the live child resources into the status of the workload, which
+	// is persisted along with the phase conditions
+	if err := {{ .Builder.GetPackageName }}.SetStatus(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }}); err != nil {
+		return false, err
+	}
+
+	{{ end }}
+	{{- if .HasReadyChecks }}
+	// check the live child resources as requested by their ready markers, setting a ready condition
+	// for each of them within the status of the workload
+	if ready, err := {{ .Builder.GetPackageName }}.CheckReady(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }}); err != nil || !ready {
+		return false, err
+	}
+
+	{{ end }}
 	return dependencies.{{ .Resource.Kind }}CheckReady(r, req)
 }
 
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
 )
 
 var _ machinery.Template = &Component{}
@@ -21,6 +22,12 @@ type Component struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
+
+	// input fields
+	Builder kinds.WorkloadBuilder
+
+	// template fields
+	HasReadyChecks bool
 }
 
 func (f *Component) SetTemplateDefaults() error {
@@ -30,6 +37,8 @@ func (f *Component) SetTemplateDefaults() error {
 		fmt.Sprintf("%s.go", utils.ToFileName(f.Resource.Kind)),
 	)
 
+	f.HasReadyChecks = len(f.Builder.GetManifests().ReadyFuncNames()) > 0
+
 	f.TemplateBody = componentTemplate
 
 	f.IfExistsAction = machinery.SkipFile
@@ -43,10 +52,32 @@ package dependencies
 
 import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	{{- if .HasReadyChecks }}
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- end }}
 )
 
 // {{ .Resource.Kind }}CheckReady performs the logic to determine if a {{ .Resource.Kind }} object is ready.
+{{- if .HasReadyChecks }}
+// The ready conditions of the child resources, as checked by their ready markers, are set within
+// the status of the object before this function is called.
+{{- end }}
 func {{ .Resource.Kind }}CheckReady(r workload.Reconciler, req *workload.Request) (bool, error) {
+	{{- if .HasReadyChecks }}
+	component, ok := req.Workload.(*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }})
+	if !ok {
+		return false, {{ .Resource.ImportAlias }}.ErrUnableToConvert{{ .Resource.Kind }}
+	}
+
+	for _, condition := range component.Status.ReadyConditions {
+		if condition.Status != metav1.ConditionTrue {
+			return false, nil
+		}
+	}
+
+	{{ end }}
 	return true, nil
 }
 `
@@ -45,7 +45,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// {{ .Resource.Kind }}Mutate performs the logic to mutate resources that belong to the parent.
+// {{ .Resource.Kind }}Mutate performs the logic to mutate resources that belong to the parent.  Resources
+// are reconciled with the policies requested by their annotations (e.g. create-only) after they are
+// mutated, so the policy annotations may also be set or removed here.
 func {{ .Resource.Kind }}Mutate(
 	r workload.Reconciler,
 	req *workload.Request,
@@ -9,7 +9,9 @@ import (
 
 var _ machinery.Template = &Makefile{}
 
-const crdOptions = "crd:preserveUnknownFields=false,crdVersions=v1,trivialVersions=true"
+// crdOptions does not include the trivialVersions and preserveUnknownFields options, which are not
+// supported by the version of controller-gen which is downloaded by the Makefile.
+const crdOptions = "crd:crdVersions=v1,allowDangerousTypes=true"
 
 // Makefile scaffolds the project Makefile.
 type Makefile struct {
@@ -37,7 +39,7 @@ func (f *Makefile) SetTemplateDefaults() error {
 const makefileTemplate = `
 # Image URL to use all building/pushing image targets
 IMG ?= controller:latest
-# Produce CRDs that work back to Kubernetes 1.11 (no version conversion)
+# Produce v1 CRDs, which are required by Kubernetes 1.22 and later
 CRD_OPTIONS ?= "{{ .CrdOptions }}"
 
 # Get the currently used golang install path (in GOPATH/bin, unless GOBIN is set)
@@ -125,14 +127,15 @@ undeploy: ## Undeploy controller from the K8s cluster specified in ~/.kube/confi
 
 
 CONTROLLER_GEN = $(shell pwd)/bin/controller-gen
+# NOTE: controller-gen v0.9.0 or later is required for the validation rules of the API fields
 controller-gen: ## Download controller-gen locally if necessary.
-	$(call go-get-tool,$(CONTROLLER_GEN),sigs.k8s.io/controller-tools/cmd/controller-gen@v0.4.1)
+	$(call go-get-tool,$(CONTROLLER_GEN),sigs.k8s.io/controller-tools/cmd/controller-gen@v0.9.2)
 
 KUSTOMIZE = $(shell pwd)/bin/kustomize
 kustomize: ## Download kustomize locally if necessary.
 	$(call go-get-tool,$(KUSTOMIZE),sigs.k8s.io/kustomize/kustomize/v3@v3.8.7)
 
-# go-get-tool will 'go get' any package $2 and install it to $1.
+# go-get-tool will 'go install' any package $2 and install it to $1.
 PROJECT_DIR := $(shell dirname $(abspath $(lastword $(MAKEFILE_LIST))))
 define go-get-tool
 @[ -f $(1) ] || { \
@@ -141,7 +144,7 @@ TMP_DIR=$$(mktemp -d) ;\
 cd $$TMP_DIR ;\
 go mod init tmp ;\
 echo "Downloading $(2)" ;\
-GOBIN=$(PROJECT_DIR)/bin go get $(2) ;\
+GOBIN=$(PROJECT_DIR)/bin go install $(2) ;\
 rm -rf $$TMP_DIR ;\
 }
 endef
@@ -121,6 +121,13 @@ const (
 	waitInterval            = 3 * time.Second
 )
 
+// annotations which request the deletion policy of a child resource, and which record the custom
+// resource from which a retained child resource was released.
+const (
+	deletionPolicyAnnotation = "operator-builder.io/deletion-policy"
+	retainedFromAnnotation   = "operator-builder.io/retained-from"
+)
+
 // deletableWhitelist is a representation of known kinds which may be
 // deleted for our test
 var deletableWhitelist = []string{
@@ -620,7 +627,27 @@ func deleteCustomResource(tester *E2ETest) error {
 		return fmt.Errorf("error deleting custom resource: %+v; %w", tester.unstructured, err)
 	}
 
-	return waitForMissingResources(tester)
+	if err := waitForMissingResources(tester); err != nil {
+		return err
+	}
+
+	return deleteReleasedResources(tester)
+}
+
+// deleteReleasedResources deletes the child resources which were orphaned or retained by their deletion
+// policy when the custom resource was deleted.
+func deleteReleasedResources(tester *E2ETest) error {
+	for _, child := range tester.children {
+		if !isReleasedOnDeletion(child) {
+			continue
+		}
+
+		if err := deleteResource(tester, child); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting released child resource: %+v; %w", child, err)
+		}
+	}
+
+	return nil
 }
 
 func deleteNamespaceForTest(tester *E2ETest) error {
@@ -647,6 +674,16 @@ func waitForMissingResources(tester *E2ETest) error {
 	// wait for the resources to be missing
 	childResourcesAreMissing := func() (bool, error) {
 		for _, child := range tester.children {
+			// we expect children which are released on deletion to remain
+			if isReleasedOnDeletion(child) {
+				released, err := isReleased(tester, child)
+				if err != nil || !released {
+					return false, err
+				}
+
+				continue
+			}
+
 			_, err := getClientForResource(tester, child).
 				Get(context.TODO(), child.GetName(), metav1.GetOptions{})
 
@@ -667,6 +704,42 @@ func waitForMissingResources(tester *E2ETest) error {
 	return waitFor(childResourcesAreMissing)
 }
 
+// isReleasedOnDeletion returns whether a child resource is orphaned or retained, rather than deleted,
+// when the custom resource is deleted.
+func isReleasedOnDeletion(child client.Object) bool {
+	switch child.GetAnnotations()[deletionPolicyAnnotation] {
+	case "orphan", "retain":
+		return true
+	default:
+		return false
+	}
+}
+
+// isReleased returns whether a child resource, which is released on deletion, remains in the cluster
+// without an owner reference to the custom resource.  Retained child resources must also be annotated
+// with the custom resource from which they were retained.
+func isReleased(tester *E2ETest, child client.Object) (bool, error) {
+	clusterChild, err := getClientForResource(tester, child).
+		Get(context.TODO(), child.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("released child resource was not found in cluster: %+v; %w", child, err)
+	}
+
+	for _, ownerReference := range clusterChild.GetOwnerReferences() {
+		if ownerReference.Kind == tester.unstructured.GetKind() && ownerReference.Name == tester.unstructured.GetName() {
+			return false, nil
+		}
+	}
+
+	if child.GetAnnotations()[deletionPolicyAnnotation] == "retain" {
+		_, found := clusterChild.GetAnnotations()[retainedFromAnnotation]
+
+		return found, nil
+	}
+
+	return true, nil
+}
+
 func waitForEqualResources(tester *E2ETest, resource client.Object) error {
 	// wait for the resources to be equal
 	childResourceIsEqual := func() (bool, error) {
@@ -812,6 +885,21 @@ func testDeleteChildResource(tester *E2ETest) error {
 	return nil
 }
 
+// testDeletionPolicies deletes the custom resource, which fails unless the child resources which are orphaned or
+// retained by their deletion policy remain in the cluster, and then recreates the custom resource for the remaining
+// tests.
+func testDeletionPolicies(tester *E2ETest) error {
+	if err := deleteCustomResource(tester); err != nil {
+		return fmt.Errorf("failed deleting custom resource with released child resources: %+v; %w", tester.unstructured, err)
+	}
+
+	if err := testCreateCustomResource(tester); err != nil {
+		return fmt.Errorf("failed recreating custom resource after deletion: %+v; %w", tester.unstructured, err)
+	}
+
+	return nil
+}
+
 func testUpdateParentResource(tester *E2ETest, desiredStateChild client.Object) error {
 	if desiredStateChild != nil {
 		// update the parent resource
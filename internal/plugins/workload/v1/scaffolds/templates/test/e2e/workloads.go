@@ -12,6 +12,7 @@ import (
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
 )
 
 const (
@@ -40,6 +41,8 @@ type WorkloadTest struct {
 	TesterSamplePath          string
 	TesterCollectionName      string
 	TesterCollectionNamespace string
+	SampleSecretName          string
+	SampleSecretKey           string
 }
 
 func (f *WorkloadTest) SetTemplateDefaults() error {
@@ -47,6 +50,8 @@ func (f *WorkloadTest) SetTemplateDefaults() error {
 	f.TesterNamespace = getTesterNamespace(f.Builder)
 	f.TesterSamplePath = getTesterSamplePath(f.Resource)
 	f.TesterName = getTesterName(f.Resource)
+	f.SampleSecretName = markers.SensitiveSampleSecretName
+	f.SampleSecretKey = markers.SensitiveSampleSecretKey
 
 	if f.Builder.GetCollection() != nil {
 		f.TesterCollectionName = getTesterCollectionName(f.Builder.GetCollection())
@@ -75,11 +80,15 @@ const e2eWorkloadsTemplate = `// +build e2e_test
 package e2e_test
 
 import (
+	{{ if ne (len .Builder.GetSensitiveFields) 0 }}"context"{{ end }}
 	"fmt"
 	"os"
 
 	"github.com/stretchr/testify/require"
 
+	{{ if ne (len .Builder.GetSensitiveFields) 0 }}corev1 "k8s.io/api/core/v1"{{ end }}
+	{{ if ne (len .Builder.GetSensitiveFields) 0 }}apierrs "k8s.io/apimachinery/pkg/api/errors"{{ end }}
+	{{ if ne (len .Builder.GetSensitiveFields) 0 }}metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"{{ end }}
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
@@ -124,6 +133,31 @@ func {{ .TesterName }}NewHarness(namespace string) *E2ETest {
 	}
 }
 
+{{ if ne (len .Builder.GetSensitiveFields) 0 -}}
+// {{ .TesterName }}CreateSampleSecret creates the secret which is referenced by the sensitive fields of the
+// sample manifest.
+func {{ .TesterName }}CreateSampleSecret(tester *E2ETest) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "{{ .SampleSecretName }}",
+			Namespace: tester.namespace,
+		},
+		StringData: map[string]string{
+			"{{ .SampleSecretKey }}": "e2e-test",
+		},
+	}
+
+	_, err := tester.suiteConfig.client.
+		CoreV1().Secrets(tester.namespace).
+		Create(context.TODO(), secret, metav1.CreateOptions{})
+	if err != nil && !apierrs.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating sample secret: %+v; %w", secret, err)
+	}
+
+	return nil
+}
+
+{{ end -}}
 {{ if .Builder.IsCollection -}}
 func (tester *E2ETest) {{ .TesterName }}Test(testSuite *E2ECollectionTestSuite) {
 {{ else }}
@@ -132,6 +166,11 @@ func (tester *E2ETest) {{ .TesterName }}Test(testSuite *E2EComponentTestSuite) {
 	testSuite.suiteConfig.tests = append(testSuite.suiteConfig.tests, tester)
 	tester.suiteConfig = &testSuite.suiteConfig
 	require.NoErrorf(testSuite.T(), tester.setup(), "failed to setup test")
+	{{- if ne (len .Builder.GetSensitiveFields) 0 }}
+
+	// create the secret referenced by the sensitive fields
+	require.NoErrorf(testSuite.T(), {{ .TesterName }}CreateSampleSecret(tester), "failed to create sample secret")
+	{{- end }}
 
 	// create the custom resource
 	require.NoErrorf(testSuite.T(), testCreateCustomResource(tester), "failed to create custom resource")
@@ -139,6 +178,12 @@ func (tester *E2ETest) {{ .TesterName }}Test(testSuite *E2EComponentTestSuite) {
 	// test the deletion of a child object
 	require.NoErrorf(testSuite.T(), testDeleteChildResource(tester), "failed to reconcile deletion of a child resource")
 
+	{{- if .Builder.GetManifests.HasDeletionPolicies }}
+
+	// test the deletion policies of the child objects
+	require.NoErrorf(testSuite.T(), testDeletionPolicies(tester), "failed to release child resources on deletion")
+	{{- end }}
+
 	// test the update of a child object
 	// TODO: need immutable fields so that we can predict which managed fields we can modify to test reconciliation
 	// see https://github.com/vmware-tanzu-labs/operator-builder/issues/67
@@ -10,6 +10,7 @@ import (
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/config"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/kinds"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/manifests"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
 )
 
@@ -27,8 +28,9 @@ type createAPIProcessor struct {
 
 // CreateAPI runs through the logic that happens when the `create api` subcommand is executed.  It is responsible
 // for the processing of manifests and the markers within them, generating source code, and setting the values
-// used during scaffolding.
-func CreateAPI(processor *config.Processor) error {
+// used during scaffolding.  The errors of all of the workloads are reported at once, up to the maximum number of
+// errors, or all of them if the maximum is 0.
+func CreateAPI(processor *config.Processor, maxErrors int) error {
 	// run through pre-processing to gather the collection and the components
 	apiProcessor := &createAPIProcessor{configProcessors: processor.GetProcessors()}
 	if err := apiProcessor.preProcess(); err != nil {
@@ -42,8 +44,12 @@ func CreateAPI(processor *config.Processor) error {
 	}
 
 	// run through processing
-	if err := apiProcessor.process(); err != nil {
-		return fmt.Errorf("%w; %s", err, ErrCreateAPIProcess)
+	errs := &manifests.Errors{Max: maxErrors}
+
+	apiProcessor.process(errs)
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return fmt.Errorf("%s:\n%w", ErrCreateAPIProcess, err)
 	}
 
 	return nil
@@ -74,47 +80,103 @@ func (apiProcessor *createAPIProcessor) preProcess() error {
 	return nil
 }
 
-func (apiProcessor *createAPIProcessor) process() error {
-	fieldMarkers := &markers.MarkerCollection{
-		FieldMarkers:           []*markers.FieldMarker{},
-		CollectionFieldMarkers: []*markers.CollectionFieldMarker{},
+// process processes the manifests and markers of each workload.  Each workload is processed,
+// even when errors were found within it or within another workload, so that the errors of all
+// of the workloads are collected at once.
+func (apiProcessor *createAPIProcessor) process(errs *manifests.Errors) {
+	markerCollection := apiProcessor.newMarkerCollection()
+
+	// set the resources and collect the markers and specs
+	for i, processor := range apiProcessor.configProcessors {
+		if errs.Full() {
+			errs.Truncated = true
+
+			return
+		}
+
+		if err := apiProcessor.setResources(i, markerCollection); err != nil {
+			errs.Add(manifests.ErrorCodeWorkload, err, processor.Path)
+		}
 	}
 
-	workloadSpecs := make([]*kinds.WorkloadSpec, len(apiProcessor.configProcessors))
+	// loop through the collected workload specs and process the resource markers, including those
+	// of the workloads whose resources were set with errors, as the child resources which could
+	// be created are still checked
+	for i, processor := range apiProcessor.configProcessors {
+		if errs.Full() {
+			errs.Truncated = true
 
-	// set the resources and collect the markers and specs
-	for i := range apiProcessor.configProcessors {
-		// get the spec and set the collection on the components
-		switch workload := apiProcessor.configProcessors[i].Workload.(type) {
-		case *kinds.StandaloneWorkload:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
-		case *kinds.WorkloadCollection:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
-		case *kinds.ComponentWorkload:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
-			workload.Spec.Collection = apiProcessor.collection
-			workload.Spec.API.Domain = apiProcessor.collection.Spec.API.Domain
+			return
 		}
 
-		if err := apiProcessor.configProcessors[i].Workload.SetResources(apiProcessor.configProcessors[i].Path); err != nil {
-			return fmt.Errorf(
-				"%w; error setting resources for workload %s",
-				err, apiProcessor.configProcessors[i].Workload.GetName(),
-			)
+		if err := apiProcessor.processMarkers(i, markerCollection); err != nil {
+			errs.Add(manifests.ErrorCodeWorkload, err, processor.Path)
 		}
+	}
+}
 
-		apiProcessor.configProcessors[i].Workload.SetRBAC()
+func (apiProcessor *createAPIProcessor) newMarkerCollection() *markers.MarkerCollection {
+	return &markers.MarkerCollection{
+		FieldMarkers:           []*markers.FieldMarker{},
+		CollectionFieldMarkers: []*markers.CollectionFieldMarker{},
+	}
+}
 
-		fieldMarkers.FieldMarkers = append(fieldMarkers.FieldMarkers, workloadSpecs[i].FieldMarkers...)
-		fieldMarkers.CollectionFieldMarkers = append(fieldMarkers.CollectionFieldMarkers, workloadSpecs[i].CollectionFieldMarkers...)
+// setResources sets the resources of a single workload, which processes the markers within its
+// manifests, and adds its field markers to the collection of markers.  The field markers which
+// were found are added even when errors were found, so that the resource markers of the other
+// workloads which reference them are not reported as errors as well.
+func (apiProcessor *createAPIProcessor) setResources(i int, markerCollection *markers.MarkerCollection) error {
+	processor := apiProcessor.configProcessors[i]
+
+	// set the collection on the components
+	if workload, ok := processor.Workload.(*kinds.ComponentWorkload); ok {
+		workload.Spec.Collection = apiProcessor.collection
+		workload.Spec.API.Domain = apiProcessor.collection.Spec.API.Domain
 	}
 
-	// loop through the collected workload specs and process the resource markers
-	for i := range workloadSpecs {
-		if err := workloadSpecs[i].ProcessResourceMarkers(fieldMarkers); err != nil {
-			return fmt.Errorf("%w; error processing resource markers", err)
-		}
+	err := processor.Workload.SetResources(processor.Path)
+
+	processor.Workload.SetRBAC()
+
+	workloadSpec := getWorkloadSpec(processor.Workload)
+
+	markerCollection.FieldMarkers = append(markerCollection.FieldMarkers, workloadSpec.FieldMarkers...)
+	markerCollection.CollectionFieldMarkers = append(markerCollection.CollectionFieldMarkers, workloadSpec.CollectionFieldMarkers...)
+
+	if err != nil {
+		return fmt.Errorf("%w; error setting resources for workload %s", err, processor.Workload.GetName())
+	}
+
+	return nil
+}
+
+// processMarkers processes the resource markers and validates the template markers of a single
+// workload against the collection of markers of all of the workloads.
+func (apiProcessor *createAPIProcessor) processMarkers(i int, markerCollection *markers.MarkerCollection) error {
+	workloadSpec := getWorkloadSpec(apiProcessor.configProcessors[i].Workload)
+
+	if err := workloadSpec.ProcessResourceMarkers(markerCollection); err != nil {
+		return fmt.Errorf("%w; error processing resource markers", err)
+	}
+
+	if err := workloadSpec.ValidateTemplateMarkers(); err != nil {
+		return fmt.Errorf("%w; error validating template markers", err)
 	}
 
 	return nil
 }
+
+// getWorkloadSpec returns the spec which is shared by all kinds of workloads.
+func getWorkloadSpec(workload kinds.WorkloadBuilder) *kinds.WorkloadSpec {
+	switch workload := workload.(type) {
+	case *kinds.StandaloneWorkload:
+		return &workload.Spec.WorkloadSpec
+	case *kinds.WorkloadCollection:
+		return &workload.Spec.WorkloadSpec
+	case *kinds.ComponentWorkload:
+		return &workload.Spec.WorkloadSpec
+	}
+
+	return &kinds.WorkloadSpec{}
+}
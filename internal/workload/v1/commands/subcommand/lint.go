@@ -0,0 +1,87 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/config"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/manifests"
+)
+
+var ErrLintProblems = errors.New("problems found by `lint` subcommand")
+
+// LintProblem is a problem which was found within a workload configuration, or within the
+// manifests and markers which it references.
+type LintProblem struct {
+	Code    manifests.ErrorCode
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// String returns the problem prefixed by its location, in the file:line:column format which is
+// understood by most editors, and followed by its code.
+func (problem *LintProblem) String() string {
+	location := manifests.Location{Filename: problem.File, Line: problem.Line, Column: problem.Column}
+
+	return fmt.Sprintf("%s: %s (%s)", location, problem.Message, problem.Code)
+}
+
+// Lint runs through the same processing of a workload configuration, its manifests and the
+// markers within them as the `create api` subcommand, without scaffolding, and returns the
+// problems which were found, up to the maximum number of problems, or all of them if the maximum
+// is 0.  Whether problems were dropped as the maximum was reached is also returned.
+func Lint(configPath string, maxErrors int) (problems []*LintProblem, truncated bool) {
+	errs := &manifests.Errors{Max: maxErrors}
+
+	processor, err := config.Parse(configPath)
+	if err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, configPath)
+
+		return newLintProblems(errs)
+	}
+
+	// run through pre-processing to load the manifests and gather the collection and the components
+	apiProcessor := &createAPIProcessor{configProcessors: processor.GetProcessors()}
+	if err := apiProcessor.preProcess(); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, configPath)
+
+		return newLintProblems(errs)
+	}
+
+	if len(apiProcessor.components) > 0 {
+		if err := processor.Workload.SetComponents(apiProcessor.components); err != nil {
+			errs.Add(manifests.ErrorCodeWorkload, err, configPath)
+
+			return newLintProblems(errs)
+		}
+	}
+
+	apiProcessor.process(errs)
+
+	return newLintProblems(errs)
+}
+
+// newLintProblems returns a problem for each error which was found.  The text of a marker may
+// span multiple lines, so the message of each problem is joined onto a single line.
+func newLintProblems(errs *manifests.Errors) (problems []*LintProblem, truncated bool) {
+	problems = make([]*LintProblem, len(errs.Errors))
+
+	for i, err := range errs.Errors {
+		problems[i] = &LintProblem{
+			Code:    err.Code,
+			File:    filepath.Clean(err.Location.Filename),
+			Line:    err.Location.Line,
+			Column:  err.Location.Column,
+			Message: strings.ReplaceAll(err.Err.Error(), "\n", ""),
+		}
+	}
+
+	return problems, errs.Truncated
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package subcommand
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/manifests"
+)
+
+const testLintWorkload = `name: test
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com
+    group: apps
+    version: v1alpha1
+    kind: Test
+    clusterScoped: false
+  resources:
+  - a.yaml
+  - b.yaml
+`
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	type problem struct {
+		code manifests.ErrorCode
+		file string
+		line int
+	}
+
+	tests := []struct {
+		name      string
+		manifests map[string]string
+		maxErrors int
+		want      []problem
+		truncated bool
+	}{
+		{
+			name: "ensure valid manifests return no problems",
+			manifests: map[string]string{
+				"a.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+data:
+  one: "1"  # +operator-builder:field:name=one,type=string
+`,
+				"b.yaml": `# +operator-builder:resource:field=one,value="1",include
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`,
+			},
+			want: []problem{},
+		},
+		{
+			name: "ensure problems of every phase are returned for every manifest",
+			manifests: map[string]string{
+				"a.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+data:
+  one: "1"  # +operator-builder:feild:name=one,type=string
+  two: "2"  # +operator-builder:field:name=two,type=strin
+  three: "3"  # +operator-builder:field:name=three,type=string,defualt="3"
+  four: four  # +operator-builder:field:name=one,type=string
+`,
+				"b.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+data:
+  one: 1  # +operator-builder:field:name=one,type=int
+---
+# +operator-builder:resource:field=missing,value=true,include
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+`,
+			},
+			want: []problem{
+				{code: manifests.ErrorCodeMarker, file: "a.yaml", line: 6},
+				{code: manifests.ErrorCodeMarker, file: "a.yaml", line: 7},
+				{code: manifests.ErrorCodeMarker, file: "a.yaml", line: 8},
+				{code: manifests.ErrorCodeAPIField, file: "b.yaml", line: 6},
+				{code: manifests.ErrorCodeResourceMarker, file: "b.yaml", line: 8},
+			},
+		},
+		{
+			name: "ensure problems are truncated at the maximum",
+			manifests: map[string]string{
+				"a.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+data:
+  one: "1"  # +operator-builder:feild:name=one,type=string
+  two: "2"  # +operator-builder:field:name=two,type=strin
+`,
+				"b.yaml": `# +operator-builder:resource:field=missing,value=true,include
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`,
+			},
+			maxErrors: 2,
+			want: []problem{
+				{code: manifests.ErrorCodeMarker, file: "a.yaml", line: 6},
+				{code: manifests.ErrorCodeMarker, file: "a.yaml", line: 7},
+			},
+			truncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			workloadPath := t.TempDir()
+			configPath := filepath.Join(workloadPath, "workload.yaml")
+
+			require.NoError(t, os.WriteFile(configPath, []byte(testLintWorkload), 0o600))
+
+			for filename, content := range tt.manifests {
+				require.NoError(t, os.WriteFile(filepath.Join(workloadPath, filename), []byte(content), 0o600))
+			}
+
+			problems, truncated := Lint(configPath, tt.maxErrors)
+
+			got := make([]problem, len(problems))
+			for i, found := range problems {
+				got[i] = problem{code: found.Code, file: filepath.Base(found.File), line: found.Line}
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.truncated, truncated)
+		})
+	}
+}
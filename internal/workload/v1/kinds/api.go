@@ -28,9 +28,21 @@ type APIFields struct {
 	Default      string
 	Sample       string
 	Last         bool
+
+	optional   bool
+	hasDefault bool
+	validation []string
 }
 
-func (api *APIFields) AddField(path string, fieldType markers.FieldType, comments []string, sample interface{}, hasDefault bool) error {
+func (api *APIFields) AddField(
+	path string,
+	fieldType markers.FieldType,
+	comments []string,
+	sample interface{},
+	hasDefault bool,
+	optional bool,
+	validation []string,
+) error {
 	obj := api
 
 	parts := strings.Split(path, ".")
@@ -71,6 +83,11 @@ func (api *APIFields) AddField(path string, fieldType markers.FieldType, comment
 	newChild.Last = true
 
 	newChild.setCommentsAndDefault(comments, sample, hasDefault)
+	newChild.setValidation(validation)
+
+	if optional {
+		newChild.setOptional()
+	}
 
 	for _, child := range obj.Children {
 		if child.manifestName == last {
@@ -79,6 +96,7 @@ func (api *APIFields) AddField(path string, fieldType markers.FieldType, comment
 			}
 
 			child.setCommentsAndDefault(comments, sample, hasDefault)
+			child.setValidation(validation)
 
 			return nil
 		}
@@ -115,6 +133,50 @@ type %[1]sSpec struct {
 	return buf.String()
 }
 
+// GetImports returns the import paths, keyed by their import alias, of the packages
+// which define the types of the API fields.
+func (api *APIFields) GetImports() map[string]string {
+	imports := map[string]string{}
+
+	if importPath := api.Type.ImportPath(); importPath != "" {
+		imports[api.Type.ImportAlias()] = importPath
+	}
+
+	for _, child := range api.Children {
+		for alias, importPath := range child.GetImports() {
+			imports[alias] = importPath
+		}
+	}
+
+	return imports
+}
+
+// GetField returns the API field, given its path relative to this API field, or nil if
+// no such field exists.
+func (api *APIFields) GetField(path string) *APIFields {
+	obj := api
+
+	for _, part := range strings.Split(path, ".") {
+		var found *APIFields
+
+		for _, child := range obj.Children {
+			if child.manifestName == part {
+				found = child
+
+				break
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		obj = found
+	}
+
+	return obj
+}
+
 func (api *APIFields) GenerateSampleSpec(requiredOnly bool) string {
 	var buf bytes.Buffer
 
@@ -146,7 +208,7 @@ func (api *APIFields) needsGenerate(requiredOnly bool) bool {
 }
 
 func (api *APIFields) hasRequiredField() bool {
-	if len(api.Children) == 0 && api.Default == "" {
+	if len(api.Children) == 0 && api.Default == "" && !api.optional {
 		return true
 	}
 
@@ -159,12 +221,32 @@ func (api *APIFields) hasRequiredField() bool {
 	return false
 }
 
+// isScalar returns whether an API field is a non-optional field of a scalar type, which may
+// always be rendered as a string.
+func (api *APIFields) isScalar() bool {
+	if api.optional || api.Type == markers.FieldStruct {
+		return false
+	}
+
+	return !api.Type.IsArray() && !api.Type.IsMap() && !api.Type.IsKubernetesType()
+}
+
 func (api *APIFields) generateAPISpecField(b io.StringWriter, kind string) {
 	typeName := api.Type.String()
 	if api.Type == markers.FieldStruct {
 		typeName = kind + api.StructName
 	}
 
+	// optional fields must be represented as pointers to distinguish an unset field from its zero value
+	if api.optional && !api.Type.IsNillable() {
+		typeName = "*" + typeName
+	}
+
+	// floats are discouraged by the CRD schema so we must explicitly represent them as numbers
+	if api.Type.IsFloat() {
+		mustWrite(b.WriteString("// +kubebuilder:validation:Type=number\n"))
+	}
+
 	for _, m := range api.Markers {
 		mustWrite(b.WriteString(fmt.Sprintf("// %s\n", m)))
 	}
@@ -209,7 +291,13 @@ func (api *APIFields) generateStructName(path string) {
 }
 
 func (api *APIFields) isEqual(input *APIFields) bool {
-	if api.Type != input.Type {
+	if api.Type != input.Type || api.optional != input.optional {
+		return false
+	}
+
+	// a field which is validated by one marker must be validated identically by every marker
+	if len(api.validation) != len(input.validation) ||
+		(len(api.validation) > 0 && !reflect.DeepEqual(api.validation, input.validation)) {
 		return false
 	}
 
@@ -264,7 +352,8 @@ func (api *APIFields) setSample(sampleVal interface{}) {
 func (api *APIFields) setDefault(sampleVal interface{}) {
 	api.Default = api.getSampleValue(sampleVal)
 
-	if len(api.Markers) == 0 {
+	if !api.hasDefault {
+		api.hasDefault = true
 		api.Markers = append(
 			api.Markers,
 			fmt.Sprintf("+kubebuilder:default=%s", api.Default),
@@ -276,6 +365,24 @@ func (api *APIFields) setDefault(sampleVal interface{}) {
 	api.setSample(sampleVal)
 }
 
+// setValidation sets the validation markers for a field.  Validation markers are only set
+// once as a field which is validated differently by another marker is rejected.
+func (api *APIFields) setValidation(validation []string) {
+	if len(api.validation) > 0 || len(validation) == 0 {
+		return
+	}
+
+	api.validation = validation
+	api.Markers = append(api.Markers, validation...)
+}
+
+// setOptional marks a field as optional.  Optional fields have no default and are omitted
+// from the child resources when unset.
+func (api *APIFields) setOptional() {
+	api.optional = true
+	api.Markers = append(api.Markers, "+kubebuilder:validation:Optional")
+}
+
 func (api *APIFields) setCommentsAndDefault(comments []string, sampleVal interface{}, hasDefault bool) {
 	if hasDefault {
 		api.setDefault(sampleVal)
@@ -110,6 +110,93 @@ func TestAPIFields_GenerateSampleSpec(t *testing.T) {
 	}
 }
 
+func TestAPIFields_GetImports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		api  *APIFields
+		want map[string]string
+	}{
+		{
+			name: "api fields without kubernetes types have no imports",
+			api: &APIFields{
+				Type: markers.FieldStruct,
+				Children: []*APIFields{
+					{Type: markers.FieldString},
+				},
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "api fields with nested kubernetes types return unique imports",
+			api: &APIFields{
+				Type: markers.FieldStruct,
+				Children: []*APIFields{
+					{Type: markers.FieldType("corev1.ResourceRequirements")},
+					{
+						Type: markers.FieldStruct,
+						Children: []*APIFields{
+							{Type: markers.FieldType("[]corev1.Toleration")},
+							{Type: markers.FieldType("metav1.LabelSelector")},
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"corev1": "k8s.io/api/core/v1",
+				"metav1": "k8s.io/apimachinery/pkg/apis/meta/v1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.api.GetImports())
+		})
+	}
+}
+
+func TestAPIFields_GetField(t *testing.T) {
+	t.Parallel()
+
+	nested := &APIFields{manifestName: "nested", Type: markers.FieldString}
+	parent := &APIFields{manifestName: "parent", Type: markers.FieldStruct, Children: []*APIFields{nested}}
+	api := &APIFields{Children: []*APIFields{parent}}
+
+	tests := []struct {
+		name string
+		path string
+		want *APIFields
+	}{
+		{
+			name: "top level field",
+			path: "parent",
+			want: parent,
+		},
+		{
+			name: "nested field",
+			path: "parent.nested",
+			want: nested,
+		},
+		{
+			name: "missing field",
+			path: "parent.missing",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, api.GetField(tt.path))
+		})
+	}
+}
+
 func TestAPIFields_generateStructName(t *testing.T) {
 	t.Parallel()
 
@@ -201,9 +288,15 @@ func TestAPIFields_hasRequiredField(t *testing.T) {
 		Default:  "default",
 	}
 
+	withOptional := &APIFields{
+		Children: []*APIFields{},
+		optional: true,
+	}
+
 	type fields struct {
 		Children []*APIFields
 		Default  string
+		optional bool
 	}
 
 	tests := []struct {
@@ -227,6 +320,14 @@ func TestAPIFields_hasRequiredField(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "flat optional api field is not a required field",
+			fields: fields{
+				Children: withOptional.Children,
+				optional: withOptional.optional,
+			},
+			want: false,
+		},
 		{
 			name: "nested api field has a required field",
 			fields: fields{
@@ -266,6 +367,7 @@ func TestAPIFields_hasRequiredField(t *testing.T) {
 			api := &APIFields{
 				Children: tt.fields.Children,
 				Default:  tt.fields.Default,
+				optional: tt.fields.optional,
 			}
 			if got := api.hasRequiredField(); got != tt.want {
 				t.Errorf("APIFields.hasRequiredField() = %v, want %v", got, tt.want)
@@ -497,6 +599,21 @@ func TestAPIFields_setSample(t *testing.T) {
 				Sample:       "struct:",
 			},
 		},
+		{
+			name: "set array sample",
+			args: args{
+				sampleVal: "[test, sample]",
+			},
+			fields: fields{
+				manifestName: "array",
+				Type:         markers.FieldType("[]string"),
+			},
+			expect: &APIFields{
+				manifestName: "array",
+				Type:         markers.FieldType("[]string"),
+				Sample:       "array: [test, sample]",
+			},
+		},
 		{
 			name: "set other sample",
 			args: args{
@@ -537,6 +654,8 @@ func TestAPIFields_setDefault(t *testing.T) {
 		Markers      []string
 		Default      string
 		Sample       string
+		hasDefault   bool
+		validation   []string
 	}
 
 	type args struct {
@@ -550,7 +669,7 @@ func TestAPIFields_setDefault(t *testing.T) {
 		expect *APIFields
 	}{
 		{
-			name: "set default for string",
+			name: "set default for string with existing default",
 			args: args{
 				sampleVal: "string",
 			},
@@ -561,6 +680,7 @@ func TestAPIFields_setDefault(t *testing.T) {
 					"marker1",
 					"marker2",
 				},
+				hasDefault: true,
 			},
 			expect: &APIFields{
 				manifestName: "string",
@@ -571,6 +691,7 @@ func TestAPIFields_setDefault(t *testing.T) {
 					"marker1",
 					"marker2",
 				},
+				hasDefault: true,
 			},
 		},
 		{
@@ -591,6 +712,33 @@ func TestAPIFields_setDefault(t *testing.T) {
 					"+kubebuilder:validation:Optional",
 					"(Default: [other])",
 				},
+				hasDefault: true,
+			},
+		},
+		{
+			name: "set default for validated field",
+			args: args{
+				sampleVal: 2,
+			},
+			fields: fields{
+				manifestName: "replicas",
+				Type:         markers.FieldInt,
+				Markers:      []string{"+kubebuilder:validation:Minimum=1"},
+				validation:   []string{"+kubebuilder:validation:Minimum=1"},
+			},
+			expect: &APIFields{
+				manifestName: "replicas",
+				Type:         markers.FieldInt,
+				Sample:       "replicas: 2",
+				Default:      "2",
+				Markers: []string{
+					"+kubebuilder:validation:Minimum=1",
+					"+kubebuilder:default=2",
+					"+kubebuilder:validation:Optional",
+					"(Default: 2)",
+				},
+				hasDefault: true,
+				validation: []string{"+kubebuilder:validation:Minimum=1"},
 			},
 		},
 	}
@@ -605,6 +753,8 @@ func TestAPIFields_setDefault(t *testing.T) {
 				Markers:      tt.fields.Markers,
 				Default:      tt.fields.Default,
 				Sample:       tt.fields.Sample,
+				hasDefault:   tt.fields.hasDefault,
+				validation:   tt.fields.validation,
 			}
 			api.setDefault(tt.args.sampleVal)
 			assert.Equal(t, tt.expect, api)
@@ -670,6 +820,7 @@ func TestAPIFields_setCommentsAndDefault(t *testing.T) {
 					"comment3",
 					"comment4",
 				},
+				hasDefault: true,
 			},
 		},
 		{
@@ -918,6 +1069,7 @@ func TestAPIFields_isEqual(t *testing.T) {
 		Children     []*APIFields
 		Default      string
 		Sample       string
+		validation   []string
 	}
 
 	type args struct {
@@ -1010,6 +1162,40 @@ func TestAPIFields_isEqual(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "different validation is not equal",
+			args: args{
+				input: &APIFields{
+					validation: []string{"+kubebuilder:validation:Minimum=1"},
+				},
+			},
+			fields: fields{
+				validation: []string{"+kubebuilder:validation:Minimum=2"},
+			},
+			want: false,
+		},
+		{
+			name: "missing validation is not equal",
+			args: args{
+				input: &APIFields{},
+			},
+			fields: fields{
+				validation: []string{"+kubebuilder:validation:Minimum=1"},
+			},
+			want: false,
+		},
+		{
+			name: "matching validation is equal",
+			args: args{
+				input: &APIFields{
+					validation: []string{"+kubebuilder:validation:Minimum=1"},
+				},
+			},
+			fields: fields{
+				validation: []string{"+kubebuilder:validation:Minimum=1"},
+			},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1027,6 +1213,7 @@ func TestAPIFields_isEqual(t *testing.T) {
 				Children:     tt.fields.Children,
 				Default:      tt.fields.Default,
 				Sample:       tt.fields.Sample,
+				validation:   tt.fields.validation,
 			}
 			got := api.isEqual(tt.args.input)
 			assert.Equal(t, tt.want, got)
@@ -1056,6 +1243,8 @@ func TestAPIFields_AddField(t *testing.T) {
 		comments   []string
 		sample     interface{}
 		hasDefault bool
+		optional   bool
+		validation []string
 	}
 
 	tests := []struct {
@@ -1184,6 +1373,84 @@ func TestAPIFields_AddField(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "conflicting validation results in an error",
+			args: args{
+				path:       "path",
+				fieldType:  markers.FieldInt,
+				comments:   []string{"test"},
+				sample:     1,
+				validation: []string{"+kubebuilder:validation:Minimum=1"},
+			},
+			fields: fields{
+				Children: []*APIFields{
+					{
+						Type:         markers.FieldInt,
+						manifestName: "path",
+						validation:   []string{"+kubebuilder:validation:Minimum=0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "matching validation",
+			args: args{
+				path:       "path",
+				fieldType:  markers.FieldInt,
+				comments:   []string{"test"},
+				sample:     1,
+				validation: []string{"+kubebuilder:validation:Minimum=1"},
+			},
+			fields: fields{
+				Children: []*APIFields{
+					{
+						Type:         markers.FieldInt,
+						manifestName: "path",
+						validation:   []string{"+kubebuilder:validation:Minimum=1"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validated and unvalidated field results in an error",
+			args: args{
+				path:      "path",
+				fieldType: markers.FieldInt,
+				comments:  []string{"test"},
+				sample:    1,
+			},
+			fields: fields{
+				Children: []*APIFields{
+					{
+						Type:         markers.FieldInt,
+						manifestName: "path",
+						validation:   []string{"+kubebuilder:validation:Minimum=1"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "optional and required field results in an error",
+			args: args{
+				path:      "path",
+				fieldType: markers.FieldInt,
+				comments:  []string{"test"},
+				sample:    1,
+				optional:  true,
+			},
+			fields: fields{
+				Children: []*APIFields{
+					{
+						Type:         markers.FieldInt,
+						manifestName: "path",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1204,7 +1471,7 @@ func TestAPIFields_AddField(t *testing.T) {
 			}
 
 			if err := api.AddField(
-				tt.args.path, tt.args.fieldType, tt.args.comments, tt.args.sample, tt.args.hasDefault,
+				tt.args.path, tt.args.fieldType, tt.args.comments, tt.args.sample, tt.args.hasDefault, tt.args.optional, tt.args.validation,
 			); (err != nil) != tt.wantErr {
 				t.Errorf("APIFields.AddField() error = %v, wantErr %v", err, tt.wantErr)
 			}
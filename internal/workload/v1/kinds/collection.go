@@ -151,25 +151,48 @@ func (c *WorkloadCollection) IsCollection() bool {
 
 func (c *WorkloadCollection) SetRBAC() {
 	c.Spec.RBACRules.Add(rbac.ForWorkloads(c))
+
+	if len(c.Spec.SensitiveFields) > 0 {
+		c.Spec.RBACRules.Add(rbac.ForSecretReferences())
+	}
 }
 
 func (c *WorkloadCollection) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(markers.FieldMarkerType, markers.CollectionMarkerType)
+	errs := &manifests.Errors{}
+
+	err := c.Spec.processManifests(
+		markers.FieldMarkerType,
+		markers.CollectionMarkerType,
+		markers.TemplateMarkerType,
+		markers.CustomMarkerType,
+	)
 	if err != nil {
-		return err
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
 	}
 
 	for _, cpt := range c.Spec.Components {
 		for _, csr := range *cpt.Spec.Manifests {
 			// add to spec fields if not present
-			err := c.Spec.processMarkers(csr, markers.CollectionMarkerType)
+			markerResults, err := c.Spec.processMarkers(csr, markers.CollectionMarkerType)
 			if err != nil {
-				return err
+				errs.AddManifest(manifests.ErrorCodeMarker, err, csr)
+			}
+
+			if err := c.Spec.processMarkerResults(markerResults); err != nil {
+				errs.AddManifest(manifests.ErrorCodeAPIField, err, csr)
 			}
 		}
 	}
 
-	return nil
+	if err := c.Spec.processSensitiveFields(c.IsClusterScoped(), c.IsClusterScoped()); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	if err := c.Spec.processPrintColumns(c.Spec.API.PrintColumns); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	return errs.ErrorOrNil()
 }
 
 func (c *WorkloadCollection) GetDependencies() []*ComponentWorkload {
@@ -198,6 +221,18 @@ func (c *WorkloadCollection) GetAPISpecFields() *APIFields {
 	return c.Spec.APISpecFields
 }
 
+func (c *WorkloadCollection) GetStatusMarkers() []*markers.StatusMarker {
+	return c.Spec.StatusMarkers
+}
+
+func (c *WorkloadCollection) GetPrintColumns() []*markers.PrintColumn {
+	return c.Spec.PrintColumns
+}
+
+func (c *WorkloadCollection) GetSensitiveFields() []*markers.SensitiveField {
+	return c.Spec.SensitiveFields
+}
+
 func (c *WorkloadCollection) GetManifests() *manifests.Manifests {
 	return c.Spec.Manifests
 }
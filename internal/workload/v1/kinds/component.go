@@ -139,15 +139,29 @@ func (*ComponentWorkload) IsCollection() bool {
 
 func (c *ComponentWorkload) SetRBAC() {
 	c.Spec.RBACRules.Add(rbac.ForWorkloads(c, c.Spec.Collection))
+
+	if len(c.Spec.SensitiveFields) > 0 {
+		c.Spec.RBACRules.Add(rbac.ForSecretReferences())
+	}
 }
 
 func (c *ComponentWorkload) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(markers.FieldMarkerType)
+	errs := &manifests.Errors{}
+
+	err := c.Spec.processManifests(markers.FieldMarkerType, markers.TemplateMarkerType, markers.CustomMarkerType)
 	if err != nil {
-		return err
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
 	}
 
-	return nil
+	if err := c.Spec.processSensitiveFields(c.IsClusterScoped(), c.Spec.Collection.IsClusterScoped()); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	if err := c.Spec.processPrintColumns(c.Spec.API.PrintColumns); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	return errs.ErrorOrNil()
 }
 
 func (c *ComponentWorkload) GetDependencies() []*ComponentWorkload {
@@ -174,6 +188,18 @@ func (c *ComponentWorkload) GetAPISpecFields() *APIFields {
 	return c.Spec.APISpecFields
 }
 
+func (c *ComponentWorkload) GetStatusMarkers() []*markers.StatusMarker {
+	return c.Spec.StatusMarkers
+}
+
+func (c *ComponentWorkload) GetPrintColumns() []*markers.PrintColumn {
+	return c.Spec.PrintColumns
+}
+
+func (c *ComponentWorkload) GetSensitiveFields() []*markers.SensitiveField {
+	return c.Spec.SensitiveFields
+}
+
 func (c *ComponentWorkload) GetManifests() *manifests.Manifests {
 	return c.Spec.Manifests
 }
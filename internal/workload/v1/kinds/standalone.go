@@ -147,15 +147,29 @@ func (*StandaloneWorkload) IsCollection() bool {
 
 func (s *StandaloneWorkload) SetRBAC() {
 	s.Spec.RBACRules.Add(rbac.ForWorkloads(s))
+
+	if len(s.Spec.SensitiveFields) > 0 {
+		s.Spec.RBACRules.Add(rbac.ForSecretReferences())
+	}
 }
 
 func (s *StandaloneWorkload) SetResources(workloadPath string) error {
-	err := s.Spec.processManifests(markers.FieldMarkerType)
+	errs := &manifests.Errors{}
+
+	err := s.Spec.processManifests(markers.FieldMarkerType, markers.TemplateMarkerType, markers.CustomMarkerType)
 	if err != nil {
-		return err
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
 	}
 
-	return nil
+	if err := s.Spec.processSensitiveFields(s.IsClusterScoped(), s.IsClusterScoped()); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	if err := s.Spec.processPrintColumns(s.Spec.API.PrintColumns); err != nil {
+		errs.Add(manifests.ErrorCodeWorkload, err, workloadPath)
+	}
+
+	return errs.ErrorOrNil()
 }
 
 func (*StandaloneWorkload) GetDependencies() []*ComponentWorkload {
@@ -183,6 +197,18 @@ func (s *StandaloneWorkload) GetAPISpecFields() *APIFields {
 	return s.Spec.APISpecFields
 }
 
+func (s *StandaloneWorkload) GetStatusMarkers() []*markers.StatusMarker {
+	return s.Spec.StatusMarkers
+}
+
+func (s *StandaloneWorkload) GetPrintColumns() []*markers.PrintColumn {
+	return s.Spec.PrintColumns
+}
+
+func (s *StandaloneWorkload) GetSensitiveFields() []*markers.SensitiveField {
+	return s.Spec.SensitiveFields
+}
+
 func (s *StandaloneWorkload) GetManifests() *manifests.Manifests {
 	return s.Spec.Manifests
 }
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/vmware-tanzu-labs/object-code-generator-for-k8s/pkg/generate"
@@ -55,6 +56,9 @@ type WorkloadBuilder interface {
 	GetCollection() *WorkloadCollection
 	GetComponents() []*ComponentWorkload
 	GetAPISpecFields() *APIFields
+	GetStatusMarkers() []*markers.StatusMarker
+	GetPrintColumns() []*markers.PrintColumn
+	GetSensitiveFields() []*markers.SensitiveField
 	GetRBACRules() *[]rbac.Rule
 	GetComponentResource(domain, repo string, clusterScoped bool) *resource.Resource
 	GetRootCommand() *companion.CLI
@@ -71,18 +75,22 @@ type WorkloadBuilder interface {
 }
 
 var (
-	ErrLoadManifests   = errors.New("error loading manifests")
-	ErrProcessManifest = errors.New("error processing manifest file")
-	ErrUniqueName      = errors.New("child resource unique name error")
+	ErrLoadManifests  = errors.New("error loading manifests")
+	ErrDecodeManifest = errors.New("error decoding manifest file")
+	ErrUniqueName     = errors.New("child resource unique name error")
+	ErrStatusName     = errors.New("status marker name error")
+	ErrPrintColumn    = errors.New("print column error")
+	ErrSensitiveField = errors.New("sensitive field error")
 )
 
 // WorkloadAPISpec contains fields shared by all workload specs.
 type WorkloadAPISpec struct {
-	Domain        string `json:"domain" yaml:"domain"`
-	Group         string `json:"group" yaml:"group"`
-	Version       string `json:"version" yaml:"version"`
-	Kind          string `json:"kind" yaml:"kind"`
-	ClusterScoped bool   `json:"clusterScoped" yaml:"clusterScoped"`
+	Domain        string   `json:"domain" yaml:"domain"`
+	Group         string   `json:"group" yaml:"group"`
+	Version       string   `json:"version" yaml:"version"`
+	Kind          string   `json:"kind" yaml:"kind"`
+	ClusterScoped bool     `json:"clusterScoped" yaml:"clusterScoped"`
+	PrintColumns  []string `json:"printColumns,omitempty" yaml:"printColumns,omitempty"`
 }
 
 // WorkloadShared contains fields shared by all workloads.
@@ -99,6 +107,10 @@ type WorkloadSpec struct {
 	Manifests              *manifests.Manifests             `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	FieldMarkers           []*markers.FieldMarker           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	CollectionFieldMarkers []*markers.CollectionFieldMarker `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	TemplateMarkers        []*markers.TemplateMarker        `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	StatusMarkers          []*markers.StatusMarker          `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	PrintColumns           []*markers.PrintColumn           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	SensitiveFields        []*markers.SensitiveField        `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	ForCollection          bool                             `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	Collection             *WorkloadCollection              `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	APISpecFields          *APIFields                       `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
@@ -120,10 +132,51 @@ func NewSampleAPISpec() *WorkloadAPISpec {
 // their respective resource markers, and generates the source code needed for that particular
 // resource marker.
 func (ws *WorkloadSpec) ProcessResourceMarkers(markerCollection *markers.MarkerCollection) error {
+	errs := &manifests.Errors{}
+
 	for _, manifest := range *ws.Manifests {
 		for i := range manifest.ChildResources {
 			if err := manifest.ChildResources[i].ProcessResourceMarkers(markerCollection); err != nil {
-				return fmt.Errorf("%w", err)
+				errs.AddDocument(manifests.ErrorCodeResourceMarker, err, manifest, i)
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidateTemplateMarkers validates that each field referenced by a template marker exists
+// within the API specification of the workload, or its collection, and that the field may
+// be rendered as a string.  References to the loop variables of a repeated resource are
+// validated when the resource markers of the child resource are processed.
+func (ws *WorkloadSpec) ValidateTemplateMarkers() error {
+	for _, templateMarker := range ws.TemplateMarkers {
+		for _, reference := range templateMarker.GetReferences() {
+			if reference.Repeat {
+				continue
+			}
+
+			apiSpecFields := ws.APISpecFields
+
+			if reference.Collection {
+				if ws.Collection == nil {
+					return fmt.Errorf("%w; %s for %s without a collection",
+						markers.ErrTemplateMarkerUnknownReference, reference, templateMarker,
+					)
+				}
+
+				apiSpecFields = ws.Collection.Spec.APISpecFields
+			}
+
+			field := apiSpecFields.GetField(reference.Name)
+			if field == nil {
+				return fmt.Errorf("%w; %s for %s", markers.ErrTemplateMarkerUnknownReference, reference, templateMarker)
+			}
+
+			if !field.isScalar() {
+				return fmt.Errorf("%w; %s of type %s for %s",
+					markers.ErrTemplateMarkerInvalidReference, reference, field.Type, templateMarker,
+				)
 			}
 		}
 	}
@@ -211,89 +264,167 @@ func (ws *WorkloadSpec) appendCollectionRef() {
 	ws.APISpecFields.Children = append(ws.APISpecFields.Children, collectionField)
 }
 
-func processManifestError(err error, manifest *manifests.Manifest) error {
-	return fmt.Errorf("%w; %s [%s]", err, ErrProcessManifest, manifest.Filename)
-}
-
+// processManifests processes the markers within the manifests of the workload and creates the
+// child resources from their documents.  The errors of all of the manifests and documents are
+// collected so that they may be reported at once.  The child resources of a manifest file are
+// created from its documents in order, so the index of a child resource is also the index of its
+// document.
 func (ws *WorkloadSpec) processManifests(markerTypes ...markers.MarkerType) error {
 	ws.init()
 
+	errs := &manifests.Errors{}
+
 	// track the unique names so that we can handle when we have an overlap
 	uniqueNames := map[string]bool{}
 
 	for _, manifestFile := range *ws.Manifests {
-		err := ws.processMarkers(manifestFile, markerTypes...)
+		// the markers which were found without errors are processed along with the documents, so
+		// that the errors which they cause are also found
+		markerResults, err := ws.processMarkers(manifestFile, markerTypes...)
 		if err != nil {
-			return err
+			errs.AddManifest(manifests.ErrorCodeMarker, err, manifestFile)
+
+			// the documents of a manifest file which could not be decoded may not be processed
+			if errors.Is(err, ErrDecodeManifest) {
+				continue
+			}
+		}
+
+		if err := ws.processMarkerResults(markerResults); err != nil {
+			errs.AddManifest(manifests.ErrorCodeAPIField, err, manifestFile)
 		}
 
 		var childResources []manifests.ChildResource
 
-		for _, manifest := range manifestFile.ExtractManifests() {
+		for document, manifest := range manifestFile.ExtractManifests() {
 			// decode manifest into unstructured data type
 			var manifestObject unstructured.Unstructured
 
 			decoder := serializer.NewCodecFactory(scheme.Scheme).UniversalDecoder()
 
 			if err := runtime.DecodeInto(decoder, []byte(manifest), &manifestObject); err != nil {
-				return fmt.Errorf(
-					"%w; %s - unable to decode object in manifest file %s",
-					err,
-					ErrProcessManifest,
-					manifestFile.Filename,
+				errs.AddDocument(
+					manifests.ErrorCodeManifest,
+					fmt.Errorf("%w; unable to decode object", err),
+					manifestFile,
+					document,
 				)
+
+				continue
 			}
 
 			// create the new child resource and validate its unique name
 			childResource, err := manifests.NewChildResource(manifestObject)
 			if err != nil {
-				return processManifestError(err, manifestFile)
+				errs.AddDocument(manifests.ErrorCodeManifest, err, manifestFile, document)
+
+				continue
 			}
 
 			if uniqueNames[childResource.UniqueName] {
-				return processManifestError(
+				errs.AddDocument(
+					manifests.ErrorCodeUniqueName,
 					fmt.Errorf(
 						"%w; error generating resource definition for resource kind [%s] with name [%s]",
 						ErrUniqueName, manifestObject.GetKind(), manifestObject.GetName(),
 					),
 					manifestFile,
+					document,
 				)
 			}
 
 			uniqueNames[childResource.UniqueName] = true
 
+			// remove the optional fields which are conditionally set on the object
+			manifestContent, optionalFieldCode, err := markers.ExtractOptionalFields([]byte(manifest), "resourceObj")
+			if err != nil {
+				errs.AddDocument(manifests.ErrorCodeMarker, err, manifestFile, document)
+
+				continue
+			}
+
 			// generate the object source code
-			resourceDefinition, err := generate.Generate([]byte(manifest), "resourceObj")
+			resourceDefinition, err := generate.Generate(manifestContent, "resourceObj")
 			if err != nil {
-				return processManifestError(
+				errs.AddDocument(
+					manifests.ErrorCodeManifest,
 					fmt.Errorf(
 						"%w; error generating resource definition for resource kind [%s] with name [%s]",
 						err, manifestObject.GetKind(), manifestObject.GetName(),
 					),
 					manifestFile,
+					document,
 				)
+
+				continue
 			}
 
-			// add the source code to the resource
-			childResource.SourceCode = resourceDefinition
+			// add the source code to the resource, building any keys which are controlled by fields
+			childResource.SourceCode = markers.ExpandKeyVariables(resourceDefinition)
+			childResource.OptionalFieldCode = markers.ExpandKeyVariables(optionalFieldCode)
 			childResource.StaticContent = manifest
 
+			// process the status markers which project fields of the child resource into the status
+			if err := ws.processStatusMarkers(childResource); err != nil {
+				errs.AddDocument(manifests.ErrorCodeStatusMarker, err, manifestFile, document)
+			}
+
+			// process the ready markers which check the readiness of the live child resource
+			if err := childResource.ProcessReadyMarkers(); err != nil {
+				errs.AddDocument(manifests.ErrorCodeReadyMarker, err, manifestFile, document)
+			}
+
 			childResources = append(childResources, *childResource)
 		}
 
 		manifestFile.ChildResources = childResources
 	}
 
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
 	// ensure no duplicate file names exist within the source files
 	ws.deduplicateFileNames()
 
 	return nil
 }
 
-func (ws *WorkloadSpec) processMarkers(manifestFile *manifests.Manifest, markerTypes ...markers.MarkerType) error {
-	nodes, markerResults, err := markers.InspectForYAML(manifestFile.Content, markerTypes...)
-	if err != nil {
-		return processManifestError(err, manifestFile)
+// processStatusMarkers processes the status markers of a child resource and associates
+// them with the workload.  Each status marker must have a unique name as it represents
+// a single field in the status of the workload.
+func (ws *WorkloadSpec) processStatusMarkers(childResource *manifests.ChildResource) error {
+	if err := childResource.ProcessStatusMarkers(); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	for _, statusMarker := range childResource.StatusMarkers {
+		for _, existing := range ws.StatusMarkers {
+			if strings.EqualFold(existing.GetName(), statusMarker.GetName()) {
+				return fmt.Errorf(
+					"%w; status field [%s] is requested by more than one status marker",
+					ErrStatusName, statusMarker.GetName(),
+				)
+			}
+		}
+
+		ws.StatusMarkers = append(ws.StatusMarkers, statusMarker)
+	}
+
+	return nil
+}
+
+// processMarkers inspects the markers within the content of a manifest file and replaces the
+// content with its processed documents.  The results of the inspection are returned so that
+// the markers may be processed, along with the errors of the markers which could not be
+// inspected.
+func (ws *WorkloadSpec) processMarkers(
+	manifestFile *manifests.Manifest,
+	markerTypes ...markers.MarkerType,
+) ([]*inspect.YAMLResult, error) {
+	nodes, markerResults, inspectErr := markers.InspectForYAML(manifestFile.Content, markerTypes...)
+	if inspectErr != nil && nodes == nil {
+		return nil, fmt.Errorf("%w; %s", inspectErr, ErrDecodeManifest)
 	}
 
 	buf := bytes.Buffer{}
@@ -301,7 +432,7 @@ func (ws *WorkloadSpec) processMarkers(manifestFile *manifests.Manifest, markerT
 	for _, node := range nodes {
 		m, err := yaml.Marshal(node)
 		if err != nil {
-			return processManifestError(err, manifestFile)
+			return nil, fmt.Errorf("%w", err)
 		}
 
 		mustWrite(buf.WriteString("---\n"))
@@ -310,25 +441,12 @@ func (ws *WorkloadSpec) processMarkers(manifestFile *manifests.Manifest, markerT
 
 	manifestFile.Content = buf.Bytes()
 
-	if err = ws.processMarkerResults(markerResults); err != nil {
-		return processManifestError(err, manifestFile)
-	}
-
-	// If processing manifests for collection resources there is no case
-	// where there should be collection markers - they will result in
-	// code that won't compile.  We will convert collection markers to
-	// field markers for the sake of UX.
-	if markers.ContainsMarkerType(markerTypes, markers.FieldMarkerType) &&
-		markers.ContainsMarkerType(markerTypes, markers.CollectionMarkerType) {
-		// find & replace collection markers with field markers
-		manifestFile.Content = []byte(strings.ReplaceAll(string(manifestFile.Content), "!!var collection", "!!var parent"))
-		manifestFile.Content = []byte(strings.ReplaceAll(string(manifestFile.Content), "!!start collection", "!!start parent"))
-	}
-
-	return nil
+	return markerResults, inspectErr
 }
 
 func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult) error {
+	var errs inspect.PositionErrors
+
 	for _, markerResult := range markerResults {
 		var defaultFound bool
 
@@ -344,6 +462,10 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 		case *markers.CollectionFieldMarker:
 			marker = t
 			ws.CollectionFieldMarkers = append(ws.CollectionFieldMarkers, t)
+		case *markers.TemplateMarker:
+			ws.TemplateMarkers = append(ws.TemplateMarkers, t)
+
+			continue
 		default:
 			continue
 		}
@@ -363,23 +485,178 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 			sampleVal = marker.GetOriginalValue()
 		}
 
+		// sensitive fields reference the secret which holds their value
+		fieldType := marker.GetFieldType()
+
+		if marker.IsSensitive() {
+			fieldType = markers.SensitiveFieldType
+		}
+
 		// add the field to the api specification
 		if err := ws.APISpecFields.AddField(
 			marker.GetName(),
-			marker.GetFieldType(),
+			fieldType,
 			comments,
 			sampleVal,
 			defaultFound,
+			marker.IsOptional(),
+			marker.GetValidationMarkers(),
 		); err != nil {
-			return err
+			errs = append(errs, inspect.NewPositionError(err, markerResult))
+		}
+
+		// add the print column to the api specification
+		if printColumn := marker.GetPrintColumn(); printColumn != nil {
+			if err := ws.addPrintColumn(printColumn); err != nil {
+				errs = append(errs, inspect.NewPositionError(err, markerResult))
+			}
 		}
 
 		marker.SetForCollection(ws.ForCollection)
 	}
 
+	return errs.ErrorOrNil()
+}
+
+// builtinPrintColumns returns the print columns, keyed by the name of the status field
+// which they display, that may be requested for the built-in status fields of a workload.
+func builtinPrintColumns() map[string]*markers.PrintColumn {
+	return map[string]*markers.PrintColumn{
+		"created": {
+			Name:     "Created",
+			Type:     "boolean",
+			JSONPath: ".status.created",
+		},
+		"dependenciesSatisfied": {
+			Name:     "Dependencies Satisfied",
+			Type:     "boolean",
+			JSONPath: ".status.dependenciesSatisfied",
+		},
+	}
+}
+
+// processPrintColumns adds the print columns which are requested for the built-in status
+// fields of a workload.
+func (ws *WorkloadSpec) processPrintColumns(statusFields []string) error {
+	for _, statusField := range statusFields {
+		printColumn, ok := builtinPrintColumns()[statusField]
+		if !ok {
+			return fmt.Errorf(
+				"%w; unsupported status field [%s] for print column - supported fields: created, dependenciesSatisfied",
+				ErrPrintColumn, statusField,
+			)
+		}
+
+		if err := ws.addPrintColumn(printColumn); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// addPrintColumn adds a print column to the workload.  A print column which displays the
+// same field as an existing print column is only added once, as collection fields may be
+// found in the manifests of several components.
+func (ws *WorkloadSpec) addPrintColumn(printColumn *markers.PrintColumn) error {
+	for _, existing := range ws.PrintColumns {
+		if existing.JSONPath == printColumn.JSONPath {
+			return nil
+		}
+
+		if existing.Name == printColumn.Name {
+			return fmt.Errorf(
+				"%w; print column name [%s] is requested for more than one field",
+				ErrPrintColumn, printColumn.Name,
+			)
+		}
+	}
+
+	ws.PrintColumns = append(ws.PrintColumns, printColumn)
+
+	return nil
+}
+
+// processSensitiveFields sets the sensitive fields whose placeholders are found within the
+// source code of the child resources.  The secret referenced by a sensitive field is read from
+// the namespace of the custom resource which references it, so sensitive fields are unsupported
+// for cluster-scoped custom resources.  The value of a sensitive field is resolved in plain text,
+// so sensitive fields are also unsupported for child resources other than secrets.
+func (ws *WorkloadSpec) processSensitiveFields(clusterScoped, collectionClusterScoped bool) error {
+	candidates := []markers.FieldMarkerProcessor{}
+
+	for _, marker := range ws.FieldMarkers {
+		candidates = append(candidates, marker)
+	}
+
+	for _, marker := range ws.CollectionFieldMarkers {
+		candidates = append(candidates, marker)
+	}
+
+	if ws.Collection != nil {
+		for _, marker := range ws.Collection.Spec.CollectionFieldMarkers {
+			candidates = append(candidates, marker)
+		}
+	}
+
+	found := map[string]bool{}
+
+	for _, marker := range candidates {
+		if !marker.IsSensitive() {
+			continue
+		}
+
+		field := markers.NewSensitiveField(marker)
+		if found[field.GetPlaceholder()] {
+			continue
+		}
+
+		childResources := ws.getSourceCodeVariableResources(field.GetVariableName())
+		if len(childResources) == 0 {
+			continue
+		}
+
+		for _, childResource := range childResources {
+			if !childResource.IsSecret() {
+				return fmt.Errorf(
+					"%w; sensitive field [%s] is unsupported for child resource %s as it may only be used within a Secret",
+					ErrSensitiveField, field.Name, childResource,
+				)
+			}
+		}
+
+		if (field.Collection && collectionClusterScoped) || (!field.Collection && clusterScoped) {
+			return fmt.Errorf(
+				"%w; sensitive field [%s] is unsupported for a cluster-scoped custom resource",
+				ErrSensitiveField, field.Name,
+			)
+		}
+
+		found[field.GetPlaceholder()] = true
+
+		ws.SensitiveFields = append(ws.SensitiveFields, field)
+	}
+
+	return nil
+}
+
+// getSourceCodeVariableResources returns the child resources whose source code references a variable.
+func (ws *WorkloadSpec) getSourceCodeVariableResources(variable string) []manifests.ChildResource {
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(variable) + `\b`)
+
+	childResources := []manifests.ChildResource{}
+
+	for _, manifest := range *ws.Manifests {
+		for _, childResource := range manifest.ChildResources {
+			if re.MatchString(childResource.SourceCode) {
+				childResources = append(childResources, childResource)
+			}
+		}
+	}
+
+	return childResources
+}
+
 // deduplicateFileNames dedeplicates the names of the files.  This is because
 // we cannot guarantee that files exist in different directories and may have
 // naming collisions.
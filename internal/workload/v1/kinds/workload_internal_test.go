@@ -0,0 +1,90 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/manifests"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
+)
+
+func TestWorkloadSpec_processSensitiveFields(t *testing.T) {
+	t.Parallel()
+
+	sensitive := true
+
+	testSensitiveMarker := &markers.FieldMarker{Name: "password", Type: markers.FieldString, Sensitive: &sensitive}
+	testMarker := &markers.FieldMarker{Name: "username", Type: markers.FieldString}
+
+	tests := []struct {
+		name           string
+		childResources []manifests.ChildResource
+		clusterScoped  bool
+		want           []*markers.SensitiveField
+		wantErr        bool
+	}{
+		{
+			name: "ensure sensitive field used within a secret is returned",
+			childResources: []manifests.ChildResource{
+				{Kind: "Secret", Name: "test", SourceCode: `"password": sensitivePassword,`},
+				{Kind: "ConfigMap", Name: "test", SourceCode: `"username": parent.Spec.Username,`},
+			},
+			want: []*markers.SensitiveField{{Name: "password"}},
+		},
+		{
+			name: "ensure sensitive field which is not used is not returned",
+			childResources: []manifests.ChildResource{
+				{Kind: "ConfigMap", Name: "test", SourceCode: `"username": parent.Spec.Username,`},
+			},
+			want: nil,
+		},
+		{
+			name: "ensure sensitive field used within a resource other than a secret produces error",
+			childResources: []manifests.ChildResource{
+				{Kind: "Secret", Name: "test", SourceCode: `"password": sensitivePassword,`},
+				{Kind: "ConfigMap", Name: "test", SourceCode: `"password": sensitivePassword,`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure sensitive field used within a secret of another group produces error",
+			childResources: []manifests.ChildResource{
+				{Group: "acme.com", Kind: "Secret", Name: "test", SourceCode: `"password": sensitivePassword,`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure sensitive field of a cluster-scoped custom resource produces error",
+			childResources: []manifests.ChildResource{
+				{Kind: "Secret", Name: "test", SourceCode: `"password": sensitivePassword,`},
+			},
+			clusterScoped: true,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{
+				Manifests:    &manifests.Manifests{{ChildResources: tt.childResources}},
+				FieldMarkers: []*markers.FieldMarker{testSensitiveMarker, testMarker},
+			}
+
+			err := ws.processSensitiveFields(tt.clusterScoped, tt.clusterScoped)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WorkloadSpec.processSensitiveFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, ws.SensitiveFields)
+			}
+		})
+	}
+}
@@ -6,10 +6,15 @@ package manifests
 import (
 	"errors"
 	"fmt"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/rbac"
 )
@@ -17,7 +22,16 @@ import (
 var (
 	ErrChildResourceResourceMarkerInspect = errors.New("error inspecting resource markers for child resource")
 	ErrChildResourceResourceMarkerProcess = errors.New("error processing resource markers for child resource")
+	ErrChildResourceIncludeMarkerProcess  = errors.New("error processing include markers for child resource")
+	ErrChildResourceRepeatMarkerInspect   = errors.New("error inspecting repeat markers for child resource")
+	ErrChildResourceRepeatMarkerProcess   = errors.New("error processing repeat markers for child resource")
+	ErrChildResourceRepeatMarkerCount     = errors.New("expected at most one repeat marker for child resource")
+	ErrChildResourceRepeatReference       = errors.New("template marker references repeat variables without a repeat marker")
 	ErrChildResourceRBACGenerate          = errors.New("error generating RBAC for child resource")
+	ErrChildResourceStatusMarkerInspect   = errors.New("error inspecting status markers for child resource")
+	ErrChildResourceStatusMarkerProcess   = errors.New("error processing status markers for child resource")
+	ErrChildResourceReadyMarkerInspect    = errors.New("error inspecting ready markers for child resource")
+	ErrChildResourceReadyMarkerProcess    = errors.New("error processing ready markers for child resource")
 )
 
 // ChildResource contains attributes for resources created by the custom resource.
@@ -26,15 +40,24 @@ var (
 // reconciliation and represent all resources which are passed in via the `spec.resources`
 // field of the workload configuration.
 type ChildResource struct {
-	Name          string
-	UniqueName    string
-	Group         string
-	Version       string
-	Kind          string
-	StaticContent string
-	SourceCode    string
-	IncludeCode   string
-	RBAC          *rbac.Rules
+	Name              string
+	UniqueName        string
+	Group             string
+	Version           string
+	Kind              string
+	StaticContent     string
+	SourceCode        string
+	OptionalFieldCode string
+	IncludeFieldCode  string
+	IncludeCode       string
+	RepeatCode        string
+	Wave              int
+	CreateOnly        bool
+	IgnoreFields      []string
+	DeletionPolicy    string
+	RBAC              *rbac.Rules
+	StatusMarkers     []*markers.StatusMarker
+	ReadyMarkers      []*markers.ReadyMarker
 }
 
 // NewChildResource returns a representation of a ChildResource object given an unstructured
@@ -67,39 +90,168 @@ func (resource ChildResource) String() string {
 }
 
 func (resource *ChildResource) ProcessResourceMarkers(markerCollection *markers.MarkerCollection) error {
+	// process the include markers which conditionally include keys or sequence items of the resource
+	includeFieldCode, err := markers.GetIncludeFieldCode([]byte(resource.StaticContent), "resourceObj", markerCollection)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceIncludeMarkerProcess, resource)
+	}
+
+	resource.IncludeFieldCode = markers.ExpandKeyVariables(includeFieldCode)
+
+	// process the repeat marker which creates a copy of the resource for each item of a field
+	if err := resource.processRepeatMarkers(markerCollection); err != nil {
+		return err
+	}
+
 	// obtain the marker results from the child resource input yaml
 	_, markerResults, err := markers.InspectForYAML([]byte(resource.StaticContent), markers.ResourceMarkerType)
 	if err != nil {
 		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerInspect, resource)
 	}
 
-	// ensure we have the expected number of resource markers
-	//   - 0: return immediately as resource markers are not required
-	//   - 1: continue processing normally
-	//   - 2: return an error notifying the user that we only expect 1
-	//        resource marker
+	// return immediately as resource markers are not required
+	if len(markerResults) == 0 {
+		return nil
+	}
+
+	// process each of the markers, all of which are combined to determine whether the
+	// resource is included and in which wave it is created
+	resourceMarkers := make([]*markers.ResourceMarker, len(markerResults))
+
+	for i, result := range markerResults {
+		marker, ok := result.Object.(markers.ResourceMarker)
+		if !ok {
+			return ErrChildResourceResourceMarkerProcess
+		}
+
+		if err := marker.Process(markerCollection); err != nil {
+			return fmt.Errorf(
+				"%w; %s for child resource %s",
+				inspect.NewPositionError(err, result), ErrChildResourceResourceMarkerProcess, resource,
+			)
+		}
+
+		resourceMarkers[i] = &marker
+	}
+
+	includeCode, err := markers.GetResourceIncludeCode(resourceMarkers)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerProcess, resource)
+	}
+
+	wave, err := markers.GetResourceWave(resourceMarkers)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerProcess, resource)
+	}
+
+	createOnly, ignoreFields, err := markers.GetResourcePolicy(resourceMarkers)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerProcess, resource)
+	}
+
+	deletionPolicy, err := markers.GetResourceDeletionPolicy(resourceMarkers)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerProcess, resource)
+	}
+
+	resource.IncludeCode = includeCode
+	resource.Wave = wave
+	resource.CreateOnly = createOnly
+	resource.IgnoreFields = ignoreFields
+	resource.DeletionPolicy = deletionPolicy.String()
+
+	return nil
+}
+
+// processRepeatMarkers processes the repeat marker of a child resource, if any, and sets
+// the source code which opens the loop that creates a copy of the resource for each item.
+func (resource *ChildResource) processRepeatMarkers(markerCollection *markers.MarkerCollection) error {
+	_, markerResults, err := markers.InspectForYAML([]byte(resource.StaticContent), markers.RepeatMarkerType)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceRepeatMarkerInspect, resource)
+	}
+
+	sourceCode := resource.SourceCode + resource.OptionalFieldCode + resource.IncludeFieldCode
+
 	if len(markerResults) == 0 {
+		// the loop variables are only declared for a repeated resource
+		if index, item := markers.ReferencesRepeatVariables(sourceCode); index || item {
+			return fmt.Errorf("%w for child resource %s", ErrChildResourceRepeatReference, resource)
+		}
+
 		return nil
 	}
 
-	//nolint: godox // depends on https://github.com/vmware-tanzu-labs/operator-builder/issues/271
-	// TODO: we need to ensure only one marker is found and return an error if we find more than one.
-	// this becomes difficult as the results are returned as yaml nodes.  for now, we just focus on the
-	// first result and all others are ignored but we should notify the user.
-	result := markerResults[0]
+	if len(markerResults) > 1 {
+		return inspect.NewPositionError(fmt.Errorf("%w %s", ErrChildResourceRepeatMarkerCount, resource), markerResults[1])
+	}
 
-	// process the marker
-	marker, ok := result.Object.(markers.ResourceMarker)
+	marker, ok := markerResults[0].Object.(markers.RepeatMarker)
 	if !ok {
-		return ErrChildResourceResourceMarkerProcess
+		return markers.ErrRepeatMarkerInvalidType
 	}
 
 	if err := marker.Process(markerCollection); err != nil {
-		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceResourceMarkerProcess, resource)
+		return fmt.Errorf(
+			"%w; %s for child resource %s",
+			inspect.NewPositionError(err, markerResults[0]), ErrChildResourceRepeatMarkerProcess, resource,
+		)
+	}
+
+	resource.RepeatCode = marker.GetLoopCode(sourceCode)
+
+	return nil
+}
+
+// ProcessStatusMarkers processes the status markers of a child resource and sets the
+// path of the field from which each status field is projected.
+func (resource *ChildResource) ProcessStatusMarkers() error {
+	nodes, markerResults, err := markers.InspectForYAML([]byte(resource.StaticContent), markers.StatusMarkerType)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceStatusMarkerInspect, resource)
+	}
+
+	for _, result := range markerResults {
+		marker, ok := result.Object.(*markers.StatusMarker)
+		if !ok {
+			return ErrChildResourceStatusMarkerProcess
+		}
+
+		if err := marker.SetSourcePath(nodes[0]); err != nil {
+			return fmt.Errorf(
+				"%w; %s for child resource %s",
+				inspect.NewPositionError(err, result), ErrChildResourceStatusMarkerProcess, resource,
+			)
+		}
+
+		resource.StatusMarkers = append(resource.StatusMarkers, marker)
+	}
+
+	return nil
+}
+
+// ProcessReadyMarkers processes the ready markers of a child resource, which request the checks
+// that determine whether the live child resource is ready.
+func (resource *ChildResource) ProcessReadyMarkers() error {
+	_, markerResults, err := markers.InspectForYAML([]byte(resource.StaticContent), markers.ReadyMarkerType)
+	if err != nil {
+		return fmt.Errorf("%w; %s for child resource %s", err, ErrChildResourceReadyMarkerInspect, resource)
 	}
 
-	if marker.GetIncludeCode() != "" {
-		resource.IncludeCode = marker.GetIncludeCode()
+	for _, result := range markerResults {
+		marker, ok := result.Object.(markers.ReadyMarker)
+		if !ok {
+			return markers.ErrReadyMarkerInvalidType
+		}
+
+		if err := marker.Process(); err != nil {
+			return fmt.Errorf(
+				"%w; %s for child resource %s",
+				inspect.NewPositionError(err, result), ErrChildResourceReadyMarkerProcess, resource,
+			)
+		}
+
+		resource.ReadyMarkers = append(resource.ReadyMarkers, &marker)
 	}
 
 	return nil
@@ -110,6 +262,83 @@ func (resource *ChildResource) CreateFuncName() string {
 	return fmt.Sprintf("Create%s", resource.UniqueName)
 }
 
+// StatusFuncName returns the status func name for a child resource.  The status func sets
+// the status fields of the parent which are projected from the child resource.
+func (resource *ChildResource) StatusFuncName() string {
+	if len(resource.StatusMarkers) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("SetStatusFor%s", resource.UniqueName)
+}
+
+// ReadyFuncName returns the ready func name for a child resource.  The ready func returns the
+// ready conditions of the live child resource as checked by its ready markers.
+func (resource *ChildResource) ReadyFuncName() string {
+	if len(resource.ReadyMarkers) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("CheckReadyFor%s", resource.UniqueName)
+}
+
+// ReadyCheckCode returns the source code snippets of the readiness checks which are requested
+// by all of the ready markers of a child resource.
+func (resource *ChildResource) ReadyCheckCode() []string {
+	checks := []string{}
+
+	for _, marker := range resource.ReadyMarkers {
+		checks = append(checks, marker.GetCheckCode()...)
+	}
+
+	return checks
+}
+
+// UsesPackage returns whether the source code which creates a child resource references a
+// package by name, e.g. the strconv package which converts the value of a field to a string.
+// The source code is scanned rather than searched so that the string literals are ignored.
+func (resource *ChildResource) UsesPackage(name string) bool {
+	sourceCode := strings.Join([]string{
+		resource.IncludeCode,
+		resource.RepeatCode,
+		resource.SourceCode,
+		resource.OptionalFieldCode,
+		resource.IncludeFieldCode,
+	}, "\n")
+
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(sourceCode))
+
+	var codeScanner scanner.Scanner
+
+	codeScanner.Init(file, []byte(sourceCode), nil, 0)
+
+	// a package is referenced by an identifier which is followed, but not preceded, by a period
+	var previous token.Token
+
+	var identifier string
+
+	for {
+		_, tok, literal := codeScanner.Scan()
+
+		switch tok {
+		case token.EOF:
+			return false
+		case token.PERIOD:
+			if identifier == name {
+				return true
+			}
+		}
+
+		identifier = ""
+		if tok == token.IDENT && previous != token.PERIOD {
+			identifier = literal
+		}
+
+		previous = tok
+	}
+}
+
 // InitFuncName returns the init func name for a child resource.
 func (resource *ChildResource) InitFuncName() string {
 	if strings.EqualFold(resource.Kind, "customresourcedefinition") {
@@ -121,15 +350,49 @@ func (resource *ChildResource) InitFuncName() string {
 
 // NameConstant returns the constant which is generated in the code for re-use.  It
 // is needed for instances that the metadata.name field is a field marker, in which
-// case we have no way to return the name constant.
+// case we have no way to return the name constant.  Repeated resources have several
+// names, so no constant is returned for them either.
 func (resource *ChildResource) NameConstant() string {
-	if strings.HasPrefix(strings.ToLower(resource.Name), "!!start") {
+	if strings.HasPrefix(strings.ToLower(resource.Name), "!!start") || resource.RepeatCode != "" {
 		return ""
 	}
 
 	return resource.Name
 }
 
+// templateNameFormat matches the format string of a name which is built by a template marker.
+var templateNameFormat = regexp.MustCompile(`^fmt\.Sprintf\(("(?:[^"\\]|\\.)*")`)
+
+// BaseName returns the name of a child resource as it is described within the generated source
+// code.  The name of a resource which is built by a template marker, such as the name of each
+// copy of a repeated resource, is the source code which builds it, so only the static parts of
+// its template are returned.
+func (resource *ChildResource) BaseName() string {
+	match := templateNameFormat.FindStringSubmatch(resource.Name)
+	if match == nil {
+		return resource.Name
+	}
+
+	format, err := strconv.Unquote(match[1])
+	if err != nil {
+		return ""
+	}
+
+	// remove the verbs which are substituted by the fields, leaving only the static parts
+	return strings.Trim(strings.NewReplacer("%%", "%", "%v", "").Replace(format), "-.")
+}
+
+// uniqueNameSourceCode is the source code which may be substituted into the name or
+// namespace of a resource by markers, and which is removed from its unique name.
+var uniqueNameSourceCode = regexp.MustCompile(`!!Start|!!End|Parent\.Spec\.|Collection\.Spec\.|Fmt\.Sprintf|%V|RepeatIndex|RepeatItem`)
+
+// uniqueNameConversion matches the source code which converts a field substituted into the name or
+// namespace of a resource into a string (e.g. strconv.Itoa), so that only the field itself remains.
+var uniqueNameConversion = regexp.MustCompile(`Strconv\.\w+\((?:Int64|Float64)?\(?([\w.]+)\)?(?:, [^)]*)?\)`)
+
+// uniqueNameInvalid matches the characters of a name which are invalid within a go identifier.
+var uniqueNameInvalid = regexp.MustCompile(`[^A-Za-z0-9]`)
+
 // uniqueName returns the unique name of a resource.  This combines the name, namespace, and kind
 // into a name that is unique.
 //
@@ -137,27 +400,37 @@ func (resource *ChildResource) NameConstant() string {
 // that these names are no longer unique.  Because of this, we dedeuplicate the function names
 // when we generate the function names to avoid collisions.
 func uniqueName(object unstructured.Unstructured) string {
-	// get the resource name taking into account appropriate yaml tags
-	resourceName := strings.ReplaceAll(strings.Title(object.GetName()), "-", "")
-	resourceName = strings.ReplaceAll(resourceName, ".", "")
-	resourceName = strings.ReplaceAll(resourceName, ":", "")
-	resourceName = strings.ReplaceAll(resourceName, "!!Start", "")
-	resourceName = strings.ReplaceAll(resourceName, "!!End", "")
-	resourceName = strings.ReplaceAll(resourceName, "ParentSpec", "")
-	resourceName = strings.ReplaceAll(resourceName, "CollectionSpec", "")
-	resourceName = strings.ReplaceAll(resourceName, " ", "")
-
-	// get the namespace name taking into account appropriate yaml tags
-	namespaceName := strings.ReplaceAll(strings.Title(object.GetNamespace()), "-", "")
-	namespaceName = strings.ReplaceAll(namespaceName, ".", "")
-	namespaceName = strings.ReplaceAll(namespaceName, ":", "")
-	namespaceName = strings.ReplaceAll(namespaceName, "!!Start", "")
-	namespaceName = strings.ReplaceAll(namespaceName, "!!End", "")
-	namespaceName = strings.ReplaceAll(namespaceName, "ParentSpec", "")
-	namespaceName = strings.ReplaceAll(namespaceName, "CollectionSpec", "")
-	namespaceName = strings.ReplaceAll(namespaceName, " ", "")
-
-	resourceName = fmt.Sprintf("%s%s%s", object.GetKind(), namespaceName, resourceName)
-
-	return resourceName
+	return fmt.Sprintf("%s%s%s", object.GetKind(), uniqueNamePart(object.GetNamespace()), uniqueNamePart(object.GetName()))
+}
+
+// uniqueNamePart returns a name, or a namespace, taking into account appropriate yaml tags and
+// the source code substituted by markers, so that it may be used as part of a go identifier.
+func uniqueNamePart(name string) string {
+	part := uniqueNameConversion.ReplaceAllString(strings.Title(name), "$1")
+	part = uniqueNameSourceCode.ReplaceAllString(part, "")
+
+	return uniqueNameInvalid.ReplaceAllString(part, "")
+}
+
+// HasPolicy returns whether the child resource requests a policy with which it is reconciled.
+func (resource *ChildResource) HasPolicy() bool {
+	return resource.CreateOnly || len(resource.IgnoreFields) > 0 || resource.IsReleasedOnDeletion()
+}
+
+// IsReleasedOnDeletion returns whether the child resource is released, rather than deleted, when
+// the custom resource is deleted.
+func (resource *ChildResource) IsReleasedOnDeletion() bool {
+	return resource.DeletionPolicy != "" &&
+		resource.DeletionPolicy != markers.ResourceMarkerDeletionPolicyDelete.String()
+}
+
+// IsSecret returns whether the child resource is a core Kubernetes secret.
+func (resource *ChildResource) IsSecret() bool {
+	return resource.Group == "" && resource.Kind == "Secret"
+}
+
+// IgnoreFieldsValue returns the paths of the fields which are ignored when the child resource is
+// updated, as they are joined within the policy annotation of the child resource.
+func (resource *ChildResource) IgnoreFieldsValue() string {
+	return strings.Join(resource.IgnoreFields, ",")
 }
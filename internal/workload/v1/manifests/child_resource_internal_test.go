@@ -0,0 +1,81 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_uniqueName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		kind      string
+		namespace string
+		objName   string
+		want      string
+	}{
+		{
+			name:    "ensure static name is title cased",
+			kind:    "ConfigMap",
+			objName: "web-store",
+			want:    "ConfigMapWebStore",
+		},
+		{
+			name:      "ensure namespace is included",
+			kind:      "ConfigMap",
+			namespace: "default",
+			objName:   "web-store",
+			want:      "ConfigMapDefaultWebStore",
+		},
+		{
+			name:    "ensure string field is removed from the source code",
+			kind:    "Deployment",
+			objName: "!!start parent.Spec.WebStoreName !!end",
+			want:    "DeploymentWebStoreName",
+		},
+		{
+			name:    "ensure int field conversion is removed from the source code",
+			kind:    "Deployment",
+			objName: "web-!!start strconv.Itoa(parent.Spec.Replicas) !!end",
+			want:    "DeploymentWebReplicas",
+		},
+		{
+			name:    "ensure int32 field conversion is removed from the source code",
+			kind:    "Deployment",
+			objName: "web-!!start strconv.FormatInt(int64(parent.Spec.Replicas), 10) !!end",
+			want:    "DeploymentWebReplicas",
+		},
+		{
+			name:    "ensure float32 field conversion is removed from the source code",
+			kind:    "Deployment",
+			objName: "web-!!start strconv.FormatFloat(float64(collection.Spec.Ratio), 'f', -1, 32) !!end",
+			want:    "DeploymentWebRatio",
+		},
+		{
+			name:    "ensure bool field conversion is removed from the source code",
+			kind:    "Deployment",
+			objName: "web-!!start strconv.FormatBool(parent.Spec.Enabled) !!end",
+			want:    "DeploymentWebEnabled",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			object := unstructured.Unstructured{}
+			object.SetKind(tt.kind)
+			object.SetNamespace(tt.namespace)
+			object.SetName(tt.objName)
+
+			assert.Equal(t, tt.want, uniqueName(object))
+		})
+	}
+}
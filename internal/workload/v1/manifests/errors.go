@@ -0,0 +1,135 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+)
+
+// ErrorCode identifies the kind of an error which was found while processing the manifests of
+// a workload.
+type ErrorCode string
+
+const (
+	ErrorCodeWorkload       ErrorCode = "workload"
+	ErrorCodeManifest       ErrorCode = "manifest"
+	ErrorCodeMarker         ErrorCode = "marker"
+	ErrorCodeAPIField       ErrorCode = "api-field"
+	ErrorCodeUniqueName     ErrorCode = "unique-name"
+	ErrorCodeResourceMarker ErrorCode = "resource-marker"
+	ErrorCodeStatusMarker   ErrorCode = "status-marker"
+	ErrorCodeReadyMarker    ErrorCode = "ready-marker"
+)
+
+// Errors are the errors which were found while processing the manifests of one or more
+// workloads.  The errors are collected, rather than returned as soon as the first error is
+// found, so that all of them may be reported at once.
+type Errors struct {
+	Errors []*LocationError
+
+	// Max is the maximum number of errors which are collected, or 0 to collect all errors.
+	Max int
+
+	// Truncated is set when errors were dropped, or processing was stopped, as the maximum number
+	// of errors was reached.
+	Truncated bool
+}
+
+// Error returns each of the errors on a single line.
+func (errs *Errors) Error() string {
+	messages := make([]string, len(errs.Errors))
+
+	for i := range errs.Errors {
+		messages[i] = strings.ReplaceAll(errs.Errors[i].Error(), "\n", "")
+	}
+
+	if errs.Truncated {
+		messages = append(messages, fmt.Sprintf("too many errors, stopped after the first %d errors", errs.Max))
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// ErrorOrNil returns the errors, or nil if no errors were found.
+func (errs *Errors) ErrorOrNil() error {
+	if len(errs.Errors) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// Full returns whether the maximum number of errors has been collected, in which case any
+// further processing should be stopped.
+func (errs *Errors) Full() bool {
+	return errs.Max > 0 && len(errs.Errors) >= errs.Max
+}
+
+// Add adds an error to the errors.  Errors which were already collected, or which were found
+// within a manifest file, keep their own code and location, while any other error is added as
+// found within the given file.
+func (errs *Errors) Add(code ErrorCode, err error, filename string) {
+	var collected *Errors
+	if errors.As(err, &collected) {
+		for _, locationErr := range collected.Errors {
+			errs.add(locationErr)
+		}
+
+		errs.Truncated = errs.Truncated || collected.Truncated
+
+		return
+	}
+
+	var locationErr *LocationError
+	if errors.As(err, &locationErr) {
+		errs.add(locationErr)
+
+		return
+	}
+
+	errs.add(&LocationError{Code: code, Location: Location{Filename: filename}, Err: err})
+}
+
+// AddManifest adds an error which was found while the content of a manifest file was inspected
+// as a whole.  Each marker which caused the error is added as a separate error at its own
+// location within the manifest file.
+func (errs *Errors) AddManifest(code ErrorCode, err error, manifest *Manifest) {
+	for _, markerErr := range splitPositionErrors(err) {
+		errs.add(&LocationError{Code: code, Location: manifest.Locate(markerErr), Err: markerErr})
+	}
+}
+
+// AddDocument adds an error which was found while a single document of a manifest file was
+// processed.  Each marker which caused the error is added as a separate error at its own
+// location within the manifest file.
+func (errs *Errors) AddDocument(code ErrorCode, err error, manifest *Manifest, document int) {
+	for _, markerErr := range splitPositionErrors(err) {
+		errs.add(&LocationError{Code: code, Location: manifest.LocateDocument(markerErr, document), Err: markerErr})
+	}
+}
+
+func (errs *Errors) add(err *LocationError) {
+	if errs.Full() {
+		errs.Truncated = true
+
+		return
+	}
+
+	errs.Errors = append(errs.Errors, err)
+}
+
+// splitPositionErrors returns each of the errors which were found for more than one marker, or
+// the error itself if it was not.
+func splitPositionErrors(err error) []error {
+	var positionErrs inspect.PositionErrors
+	if errors.As(err, &positionErrs) {
+		return positionErrs
+	}
+
+	return []error{err}
+}
@@ -0,0 +1,276 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+)
+
+var (
+	errTestFirst  = errors.New("first error")
+	errTestSecond = errors.New("second error")
+	errTestThird  = errors.New("third error")
+)
+
+func TestErrors_Add(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		max           int
+		err           error
+		want          []*LocationError
+		wantTruncated bool
+	}{
+		{
+			name: "ensure error is added as found within the file",
+			err:  errTestFirst,
+			want: []*LocationError{
+				{Code: ErrorCodeWorkload, Location: Location{Filename: "workload.yaml"}, Err: errTestFirst},
+			},
+		},
+		{
+			name: "ensure location error keeps its own code and location",
+			err: fmt.Errorf("%w; wrapped", &LocationError{
+				Code:     ErrorCodeMarker,
+				Location: Location{Filename: "manifest.yaml", Line: 3, Column: 5},
+				Err:      errTestFirst,
+			}),
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "manifest.yaml", Line: 3, Column: 5}, Err: errTestFirst},
+			},
+		},
+		{
+			name: "ensure nested errors are merged with their own codes and locations",
+			err: &Errors{
+				Errors: []*LocationError{
+					{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml", Line: 1}, Err: errTestFirst},
+					{Code: ErrorCodeAPIField, Location: Location{Filename: "second.yaml", Line: 2}, Err: errTestSecond},
+				},
+			},
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml", Line: 1}, Err: errTestFirst},
+				{Code: ErrorCodeAPIField, Location: Location{Filename: "second.yaml", Line: 2}, Err: errTestSecond},
+			},
+		},
+		{
+			name: "ensure nested errors which were truncated truncate the errors",
+			err: &Errors{
+				Errors: []*LocationError{
+					{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml"}, Err: errTestFirst},
+				},
+				Max:       1,
+				Truncated: true,
+			},
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml"}, Err: errTestFirst},
+			},
+			wantTruncated: true,
+		},
+		{
+			name: "ensure nested errors beyond the maximum are dropped",
+			max:  2,
+			err: &Errors{
+				Errors: []*LocationError{
+					{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml"}, Err: errTestFirst},
+					{Code: ErrorCodeMarker, Location: Location{Filename: "second.yaml"}, Err: errTestSecond},
+					{Code: ErrorCodeMarker, Location: Location{Filename: "third.yaml"}, Err: errTestThird},
+				},
+			},
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "first.yaml"}, Err: errTestFirst},
+				{Code: ErrorCodeMarker, Location: Location{Filename: "second.yaml"}, Err: errTestSecond},
+			},
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := &Errors{Max: tt.max}
+			errs.Add(ErrorCodeWorkload, tt.err, "workload.yaml")
+
+			assert.Equal(t, tt.want, errs.Errors)
+			assert.Equal(t, tt.wantTruncated, errs.Truncated)
+		})
+	}
+}
+
+func TestErrors_AddManifest(t *testing.T) {
+	t.Parallel()
+
+	manifest := &Manifest{Filename: "test.yaml", source: []byte(testLocationManifest)}
+
+	firstErr := &inspect.PositionError{
+		Position:   inspect.Position{Line: 1, Column: 3},
+		MarkerText: "+operator-builder:resource:field=provider,value=\"aws\",include",
+		Err:        errTestFirst,
+	}
+
+	secondErr := &inspect.PositionError{
+		Position:   inspect.Position{Line: 7, Column: 18},
+		MarkerText: "+operator-builder:field:name=provider,type=string",
+		Err:        errTestSecond,
+	}
+
+	tests := []struct {
+		name string
+		max  int
+		err  error
+		want []*LocationError
+	}{
+		{
+			name: "ensure error of a single marker is added at its location",
+			err:  firstErr,
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 1, Column: 3}, Err: firstErr},
+			},
+		},
+		{
+			name: "ensure errors of several markers are split and added at their own locations",
+			err:  inspect.PositionErrors{firstErr, secondErr},
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 1, Column: 3}, Err: firstErr},
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 7, Column: 18}, Err: secondErr},
+			},
+		},
+		{
+			name: "ensure wrapped errors of several markers are split",
+			err:  fmt.Errorf("%w; wrapped", inspect.PositionErrors{firstErr, secondErr}),
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 1, Column: 3}, Err: firstErr},
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 7, Column: 18}, Err: secondErr},
+			},
+		},
+		{
+			name: "ensure error without a position is added at the file",
+			err:  errTestThird,
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml"}, Err: errTestThird},
+			},
+		},
+		{
+			name: "ensure split errors beyond the maximum are dropped",
+			max:  1,
+			err:  inspect.PositionErrors{firstErr, secondErr},
+			want: []*LocationError{
+				{Code: ErrorCodeMarker, Location: Location{Filename: "test.yaml", Line: 1, Column: 3}, Err: firstErr},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := &Errors{Max: tt.max}
+			errs.AddManifest(ErrorCodeMarker, tt.err, manifest)
+
+			assert.Equal(t, tt.want, errs.Errors)
+		})
+	}
+}
+
+func TestErrors_Full(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		max   int
+		count int
+		want  bool
+	}{
+		{
+			name:  "ensure errors without a maximum are never full",
+			max:   0,
+			count: 5,
+			want:  false,
+		},
+		{
+			name:  "ensure errors below the maximum are not full",
+			max:   3,
+			count: 2,
+			want:  false,
+		},
+		{
+			name:  "ensure errors at the maximum are full",
+			max:   3,
+			count: 3,
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := &Errors{Max: tt.max}
+			for i := 0; i < tt.count; i++ {
+				errs.Add(ErrorCodeWorkload, errTestFirst, "workload.yaml")
+			}
+
+			assert.Equal(t, tt.want, errs.Full())
+			assert.False(t, errs.Truncated)
+		})
+	}
+}
+
+func TestErrors_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		max   int
+		errs  []error
+		want  string
+		isNil bool
+	}{
+		{
+			name:  "ensure no errors returns nil",
+			isNil: true,
+		},
+		{
+			name: "ensure each error is returned on a single line",
+			errs: []error{errTestFirst, fmt.Errorf("second\nerror")},
+			want: "workload.yaml: first error (workload)\nworkload.yaml: seconderror (workload)",
+		},
+		{
+			name: "ensure truncated errors report the maximum",
+			max:  1,
+			errs: []error{errTestFirst, errTestSecond},
+			want: "workload.yaml: first error (workload)\ntoo many errors, stopped after the first 1 errors",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := &Errors{Max: tt.max}
+			for _, err := range tt.errs {
+				errs.Add(ErrorCodeWorkload, err, "workload.yaml")
+			}
+
+			if tt.isNil {
+				assert.Nil(t, errs.ErrorOrNil())
+
+				return
+			}
+
+			assert.EqualError(t, errs.ErrorOrNil(), tt.want)
+		})
+	}
+}
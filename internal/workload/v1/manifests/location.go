@@ -0,0 +1,182 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+)
+
+// Location is the location of a problem within a manifest file.  The line and column start
+// from 1 and are unset when the problem could not be found at a specific position.
+type Location struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+// String returns the location in the file:line:column format which is understood by most
+// editors.
+func (location Location) String() string {
+	switch {
+	case location.Line > 0 && location.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", location.Filename, location.Line, location.Column)
+	case location.Line > 0:
+		return fmt.Sprintf("%s:%d", location.Filename, location.Line)
+	default:
+		return location.Filename
+	}
+}
+
+// LocationError is an error which was found while processing a manifest file, along with the
+// code which identifies the kind of error and the location within the manifest file at which
+// it was found.
+type LocationError struct {
+	Code     ErrorCode
+	Location Location
+	Err      error
+}
+
+func (e *LocationError) Error() string {
+	if e.Location.Filename == "" {
+		return fmt.Sprintf("%s (%s)", e.Err, e.Code)
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", e.Location, e.Err, e.Code)
+}
+
+func (e *LocationError) Unwrap() error {
+	return e.Err
+}
+
+// Locate returns the location of an error which was found while the content of the manifest
+// file was inspected as a whole.  The content may have been processed before it was inspected,
+// e.g. by the collection of a component, in which case the marker is found by its text nearest
+// to the position at which it was inspected.
+func (manifest *Manifest) Locate(err error) Location {
+	location := Location{Filename: manifest.Filename}
+
+	var positionErr *inspect.PositionError
+	if !errors.As(err, &positionErr) {
+		return location
+	}
+
+	location.Line = positionErr.Position.Line
+	location.Column = positionErr.Position.Column
+
+	markerText := firstLine(positionErr.MarkerText)
+	if markerText == "" || manifest.source == nil {
+		return location
+	}
+
+	lines := strings.Split(string(manifest.source), "\n")
+
+	if location.Line > 0 && location.Line <= len(lines) && strings.Contains(lines[location.Line-1], markerText) {
+		return location
+	}
+
+	for distance := 1; distance < len(lines); distance++ {
+		for _, line := range []int{location.Line - distance, location.Line + distance} {
+			if line < 1 || line > len(lines) {
+				continue
+			}
+
+			if index := strings.Index(lines[line-1], markerText); index >= 0 {
+				return Location{Filename: manifest.Filename, Line: line, Column: index + 1}
+			}
+		}
+	}
+
+	return location
+}
+
+// LocateDocument returns the location of an error which was found while a single document of
+// the manifest file was processed.  The document is re-marshaled before it is processed, so
+// the position of a marker within it does not match the manifest file and the marker is instead
+// found by its text.  Errors which were not found for a marker are located at the start of the
+// document.
+func (manifest *Manifest) LocateDocument(err error, document int) Location {
+	location := Location{Filename: manifest.Filename}
+
+	starts := documentStarts(manifest.source)
+	if document < 0 || document >= len(starts) {
+		return location
+	}
+
+	location.Line = starts[document]
+
+	var positionErr *inspect.PositionError
+	if !errors.As(err, &positionErr) {
+		return location
+	}
+
+	markerText := firstLine(positionErr.MarkerText)
+	if markerText == "" {
+		return location
+	}
+
+	lines := strings.Split(string(manifest.source), "\n")
+
+	// the markers of a document are found after the separator which precedes it, so that the
+	// same marker within a previous document is not found instead
+	first := 1
+
+	for line := location.Line - 1; document > 0 && line >= 1; line-- {
+		if strings.HasPrefix(lines[line-1], "---") {
+			first = line + 1
+
+			break
+		}
+	}
+
+	for line := first; line <= len(lines); line++ {
+		if index := strings.Index(lines[line-1], markerText); index >= 0 {
+			location.Line = line
+			location.Column = index + 1
+
+			return location
+		}
+	}
+
+	return location
+}
+
+// firstLine returns the first line of the text of a marker, which is the line that may be found
+// within the manifest file.
+func firstLine(markerText string) string {
+	return strings.SplitN(strings.TrimSpace(markerText), "\n", 2)[0]
+}
+
+// documentStarts returns the line at which the content of each document of a manifest file
+// starts, ignoring the comments which precede it.
+func documentStarts(content []byte) []int {
+	starts := []int{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var node yaml.Node
+
+		if err := decoder.Decode(&node); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return starts
+		}
+
+		if len(node.Content) > 0 {
+			starts = append(starts, node.Content[0].Line)
+		} else {
+			starts = append(starts, node.Line)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manifests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+)
+
+var errTestLocation = errors.New("test error")
+
+const testLocationManifest = `# +operator-builder:resource:field=provider,value="aws",include
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+data:
+  provider: aws  # +operator-builder:field:name=provider,type=string
+---
+# +operator-builder:resource:field=provider,value="aws",include
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+data:
+  provider: aws  # +operator-builder:field:name=provider,type=string
+`
+
+func TestManifest_Locate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		err     error
+		want    Location
+	}{
+		{
+			name:    "ensure error without a position is located at the file",
+			content: testLocationManifest,
+			err:     errTestLocation,
+			want:    Location{Filename: "test.yaml"},
+		},
+		{
+			name:    "ensure error is located at its position when the marker is found there",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 7, Column: 18},
+				MarkerText: "+operator-builder:field:name=provider,type=string",
+				Err:        errTestLocation,
+			},
+			want: Location{Filename: "test.yaml", Line: 7, Column: 18},
+		},
+		{
+			name:    "ensure error of a collection processed manifest is located at the nearest marker",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 12, Column: 1},
+				MarkerText: "+operator-builder:field:name=provider,type=string",
+				Err:        errTestLocation,
+			},
+			want: Location{Filename: "test.yaml", Line: 15, Column: 20},
+		},
+		{
+			name:    "ensure error of a multi-line marker is located by its first line",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 3, Column: 1},
+				MarkerText: "+operator-builder:resource:field=provider,value=\"aws\",include\n# continued",
+				Err:        errTestLocation,
+			},
+			want: Location{Filename: "test.yaml", Line: 1, Column: 3},
+		},
+		{
+			name:    "ensure error of a marker which is not found is located at its position",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 4, Column: 2},
+				MarkerText: "+operator-builder:field:name=missing,type=string",
+				Err:        errTestLocation,
+			},
+			want: Location{Filename: "test.yaml", Line: 4, Column: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := &Manifest{Filename: "test.yaml", source: []byte(tt.content)}
+
+			assert.Equal(t, tt.want, manifest.Locate(tt.err))
+		})
+	}
+}
+
+func TestManifest_Locate_collection(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "test.yaml")
+
+	require.NoError(t, os.WriteFile(filename, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  provider: aws  # +operator-builder:collection:field:name=provider,type=string
+`), 0o600))
+
+	manifest := &Manifest{Filename: filename}
+	require.NoError(t, manifest.LoadContent(true))
+
+	// the collection markers of the manifest of a collection are processed as field markers
+	err := &inspect.PositionError{
+		Position:   inspect.Position{Line: 2, Column: 1},
+		MarkerText: "+operator-builder:field:name=provider,type=string",
+		Err:        errTestLocation,
+	}
+
+	assert.Equal(t, Location{Filename: filename, Line: 6, Column: 20}, manifest.Locate(err))
+}
+
+func TestManifest_LocateDocument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		err      error
+		document int
+		want     Location
+	}{
+		{
+			name:     "ensure error without a position is located at the start of the first document",
+			content:  testLocationManifest,
+			err:      errTestLocation,
+			document: 0,
+			want:     Location{Filename: "test.yaml", Line: 2},
+		},
+		{
+			name:     "ensure error without a position is located at the start of a later document",
+			content:  testLocationManifest,
+			err:      errTestLocation,
+			document: 1,
+			want:     Location{Filename: "test.yaml", Line: 10},
+		},
+		{
+			name:    "ensure error is located at the marker of the first document",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 1, Column: 1},
+				MarkerText: "+operator-builder:resource:field=provider,value=\"aws\",include",
+				Err:        errTestLocation,
+			},
+			document: 0,
+			want:     Location{Filename: "test.yaml", Line: 1, Column: 3},
+		},
+		{
+			name:    "ensure error is located at the marker of a later document rather than the same marker before it",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 1, Column: 1},
+				MarkerText: "+operator-builder:resource:field=provider,value=\"aws\",include",
+				Err:        errTestLocation,
+			},
+			document: 1,
+			want:     Location{Filename: "test.yaml", Line: 9, Column: 3},
+		},
+		{
+			name:    "ensure error is located at the marker of a later document rather than the same marker within another",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 6, Column: 18},
+				MarkerText: "+operator-builder:field:name=provider,type=string",
+				Err:        errTestLocation,
+			},
+			document: 1,
+			want:     Location{Filename: "test.yaml", Line: 15, Column: 20},
+		},
+		{
+			name:    "ensure error of a marker which is not found is located at the start of the document",
+			content: testLocationManifest,
+			err: &inspect.PositionError{
+				Position:   inspect.Position{Line: 1, Column: 1},
+				MarkerText: "+operator-builder:field:name=missing,type=string",
+				Err:        errTestLocation,
+			},
+			document: 1,
+			want:     Location{Filename: "test.yaml", Line: 10},
+		},
+		{
+			name:     "ensure error of a document which does not exist is located at the file",
+			content:  testLocationManifest,
+			err:      errTestLocation,
+			document: 2,
+			want:     Location{Filename: "test.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := &Manifest{Filename: "test.yaml", source: []byte(tt.content)}
+
+			assert.Equal(t, tt.want, manifest.LocateDocument(tt.err, tt.document))
+		})
+	}
+}
+
+func Test_documentStarts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []int
+	}{
+		{
+			name:    "ensure the start of each document ignores its preceding comments",
+			content: testLocationManifest,
+			want:    []int{2, 10},
+		},
+		{
+			name:    "ensure a document without a separator starts at its content",
+			content: "\n\napiVersion: v1\nkind: ConfigMap\n",
+			want:    []int{3},
+		},
+		{
+			name:    "ensure an empty manifest has no documents",
+			content: "",
+			want:    []int{},
+		},
+		{
+			name:    "ensure an invalid manifest has no documents",
+			content: "key: [",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, documentStarts([]byte(tt.content)))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
@@ -22,6 +23,10 @@ type Manifest struct {
 	Filename       string          `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	SourceFilename string          `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	ChildResources []ChildResource `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+
+	// source is the content of the manifest file as it was loaded, which is kept so that problems
+	// may be located within it once the content has been processed
+	source []byte
 }
 
 // Manifests represents a collection of manifests.
@@ -97,6 +102,8 @@ func (manifest *Manifest) LoadContent(isCollection bool) error {
 		manifest.Content = manifestContent
 	}
 
+	manifest.source = manifest.Content
+
 	return nil
 }
 
@@ -118,10 +125,72 @@ func FromFiles(manifestFiles []string) *Manifests {
 // FuncNames returns the function names for a set of resources.  The function names are derived
 // from the child resource unique names and refer to the functions that actually create the
 // child resource objects in memory for the purposes of shipping to the Kubernetes API for
-// deployment into the cluster.
+// deployment into the cluster.  The create func names are ordered by the wave in which their
+// child resources are created, and then by the order in which they appear in the manifests.
 func (manifests Manifests) FuncNames() (createFuncNames, initFuncNames []string) {
+	createFuncWaves, _, initFuncNames := manifests.funcNames()
+
+	for _, wave := range createFuncWaves {
+		createFuncNames = append(createFuncNames, wave...)
+	}
+
+	return createFuncNames, initFuncNames
+}
+
+// CreateFuncWaves returns the create func names for a set of resources grouped by the wave in
+// which their child resources are created.  The waves are returned in the order in which they
+// are created, which is the ascending order of the waves requested by the resource markers.
+func (manifests Manifests) CreateFuncWaves() [][]string {
+	createFuncWaves, _, _ := manifests.funcNames()
+
+	return createFuncWaves
+}
+
+// ReadyFuncWaves returns the ready func names for a set of resources grouped by the wave in
+// which their child resources are created, in the same order as the create func names which
+// are returned by CreateFuncWaves.  Child resources without ready markers have an empty ready
+// func name.
+func (manifests Manifests) ReadyFuncWaves() [][]string {
+	_, readyFuncWaves, _ := manifests.funcNames()
+
+	return readyFuncWaves
+}
+
+// HasPolicies returns whether any of the child resources for a set of resources request a policy
+// with which they are reconciled.
+func (manifests Manifests) HasPolicies() bool {
+	for m := range manifests {
+		for i := range manifests[m].ChildResources {
+			if manifests[m].ChildResources[i].HasPolicy() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasDeletionPolicies returns whether any of the child resources for a set of resources are
+// released, rather than deleted, when the custom resource is deleted.
+func (manifests Manifests) HasDeletionPolicies() bool {
+	for m := range manifests {
+		for i := range manifests[m].ChildResources {
+			if manifests[m].ChildResources[i].IsReleasedOnDeletion() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// funcNames returns the create func names and the ready func names, grouped by wave, and the
+// init func names for a set of resources.
+func (manifests Manifests) funcNames() (createFuncWaves, readyFuncWaves [][]string, initFuncNames []string) {
 	foundCreateNames := make(map[string]int)
 	foundInitNames := make(map[string]int)
+	waveFuncNames := make(map[int][]string)
+	waveReadyFuncNames := make(map[int][]string)
 
 	for m := range manifests {
 		childResources := manifests[m].ChildResources
@@ -133,7 +202,11 @@ func (manifests Manifests) FuncNames() (createFuncNames, initFuncNames []string)
 				createFuncName = fmt.Sprintf("%s%v", createFuncName, foundCreateNames[createFuncName])
 			}
 			foundCreateNames[createFuncName]++
-			createFuncNames = append(createFuncNames, createFuncName)
+			waveFuncNames[childResources[i].Wave] = append(waveFuncNames[childResources[i].Wave], createFuncName)
+			waveReadyFuncNames[childResources[i].Wave] = append(
+				waveReadyFuncNames[childResources[i].Wave],
+				childResources[i].ReadyFuncName(),
+			)
 
 			// retrieve the init func names
 			initFuncName := childResources[i].InitFuncName()
@@ -149,7 +222,85 @@ func (manifests Manifests) FuncNames() (createFuncNames, initFuncNames []string)
 		}
 	}
 
-	return createFuncNames, initFuncNames
+	waves := make([]int, 0, len(waveFuncNames))
+	for wave := range waveFuncNames {
+		waves = append(waves, wave)
+	}
+
+	sort.Ints(waves)
+
+	for _, wave := range waves {
+		createFuncWaves = append(createFuncWaves, waveFuncNames[wave])
+		readyFuncWaves = append(readyFuncWaves, waveReadyFuncNames[wave])
+	}
+
+	return createFuncWaves, readyFuncWaves, initFuncNames
+}
+
+// StatusFuncNames returns the function names which set the status fields of the parent
+// that are projected from the child resources.  Only child resources with status markers
+// have a status function.
+func (manifests Manifests) StatusFuncNames() []string {
+	var statusFuncNames []string
+
+	for m := range manifests {
+		statusFuncNames = append(statusFuncNames, manifests[m].StatusFuncNames()...)
+	}
+
+	return statusFuncNames
+}
+
+// StatusFuncNames returns the function names which set the status fields of the parent
+// that are projected from the child resources of a single manifest.
+func (manifest *Manifest) StatusFuncNames() []string {
+	var statusFuncNames []string
+
+	for i := range manifest.ChildResources {
+		if statusFuncName := manifest.ChildResources[i].StatusFuncName(); statusFuncName != "" {
+			statusFuncNames = append(statusFuncNames, statusFuncName)
+		}
+	}
+
+	return statusFuncNames
+}
+
+// ReadyFuncNames returns the function names which return the ready conditions of the child
+// resources.  Only child resources with ready markers have a ready function.
+func (manifests Manifests) ReadyFuncNames() []string {
+	var readyFuncNames []string
+
+	for m := range manifests {
+		readyFuncNames = append(readyFuncNames, manifests[m].ReadyFuncNames()...)
+	}
+
+	return readyFuncNames
+}
+
+// ReadyFuncNames returns the function names which return the ready conditions of the child
+// resources of a single manifest.
+func (manifest *Manifest) ReadyFuncNames() []string {
+	var readyFuncNames []string
+
+	for i := range manifest.ChildResources {
+		if readyFuncName := manifest.ChildResources[i].ReadyFuncName(); readyFuncName != "" {
+			readyFuncNames = append(readyFuncNames, readyFuncName)
+		}
+	}
+
+	return readyFuncNames
+}
+
+// UsesPackage returns whether the source code which creates any of the child resources of a
+// single manifest references a package by name, in which case the package must be imported by
+// the generated source file.
+func (manifest *Manifest) UsesPackage(name string) bool {
+	for i := range manifest.ChildResources {
+		if manifest.ChildResources[i].UsesPackage(name) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // getSourceFilename returns the unique file name for a source file.
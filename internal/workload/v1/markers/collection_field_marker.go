@@ -74,6 +74,34 @@ func (cfm *CollectionFieldMarker) GetReplaceText() string {
 	return *cfm.Replace
 }
 
+func (cfm *CollectionFieldMarker) GetMerge() bool {
+	if cfm.Merge == nil {
+		return false
+	}
+
+	return *cfm.Merge
+}
+
+func (cfm *CollectionFieldMarker) IsOptional() bool {
+	if cfm.Optional == nil {
+		return false
+	}
+
+	return *cfm.Optional
+}
+
+func (cfm *CollectionFieldMarker) IsSensitive() bool {
+	if cfm.Sensitive == nil {
+		return false
+	}
+
+	return *cfm.Sensitive
+}
+
+func (cfm *CollectionFieldMarker) GetTarget() FieldTarget {
+	return cfm.Target
+}
+
 func (cfm *CollectionFieldMarker) GetSpecPrefix() string {
 	return CollectionFieldSpecPrefix
 }
@@ -86,6 +114,14 @@ func (cfm *CollectionFieldMarker) GetOriginalValue() interface{} {
 	return cfm.originalValue
 }
 
+func (cfm *CollectionFieldMarker) GetValidationMarkers() []string {
+	return cfm.validationMarkers
+}
+
+func (cfm *CollectionFieldMarker) GetPrintColumn() *PrintColumn {
+	return cfm.printColumn
+}
+
 func (cfm *CollectionFieldMarker) IsCollectionFieldMarker() bool {
 	return true
 }
@@ -99,6 +135,12 @@ func (cfm *CollectionFieldMarker) IsForCollection() bool {
 }
 
 func (cfm *CollectionFieldMarker) SetOriginalValue(value string) {
+	if cfm.IsSensitive() {
+		cfm.originalValue = sensitiveSampleValue
+
+		return
+	}
+
 	if cfm.GetReplaceText() != "" {
 		cfm.originalValue = cfm.GetReplaceText()
 
@@ -315,6 +315,94 @@ func TestCollectionFieldMarker_GetReplaceText(t *testing.T) {
 	}
 }
 
+func TestCollectionFieldMarker_GetMerge(t *testing.T) {
+	t.Parallel()
+
+	cfmMerge := true
+
+	type fields struct {
+		Merge *bool
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "ensure field merge returns as expected",
+			fields: fields{
+				Merge: &cfmMerge,
+			},
+			want: true,
+		},
+		{
+			name: "ensure field merge with empty value returns as expected",
+			fields: fields{
+				Merge: nil,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfm := &CollectionFieldMarker{
+				Merge: tt.fields.Merge,
+			}
+			if got := cfm.GetMerge(); got != tt.want {
+				t.Errorf("CollectionFieldMarker.GetMerge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectionFieldMarker_IsOptional(t *testing.T) {
+	t.Parallel()
+
+	cfmOptional := true
+
+	type fields struct {
+		Optional *bool
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "ensure field optional returns as expected",
+			fields: fields{
+				Optional: &cfmOptional,
+			},
+			want: true,
+		},
+		{
+			name: "ensure field optional with empty value returns as expected",
+			fields: fields{
+				Optional: nil,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfm := &CollectionFieldMarker{
+				Optional: tt.fields.Optional,
+			}
+			if got := cfm.IsOptional(); got != tt.want {
+				t.Errorf("CollectionFieldMarker.IsOptional() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCollectionFieldMarker_GetSpecPrefix(t *testing.T) {
 	t.Parallel()
 
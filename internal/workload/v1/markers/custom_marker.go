@@ -0,0 +1,124 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrCustomMarkerInvalidName      = errors.New("custom marker name is invalid")
+	ErrCustomMarkerInvalidType      = errors.New("custom marker type is invalid")
+	ErrCustomMarkerInvalidTransform = errors.New("custom marker transform is missing")
+	ErrCustomMarkerDuplicate        = errors.New("custom marker is already registered")
+)
+
+// builtinMarkerPrefix is the prefix of the built-in markers, which may not be used by custom markers.
+const builtinMarkerPrefix = "+operator-builder"
+
+// CustomMarkerTransform transforms the YAML nodes which are marked by a custom marker, before
+// the source code of the child resources is generated from them.  The object is the marker
+// itself, as a value of the type which it was registered with.
+type CustomMarkerTransform func(object interface{}, markerText string, nodes []*yaml.Node) error
+
+// customMarker is a marker which is defined outside of operator-builder, along with the
+// transform which is called for each of the markers which is found.
+type customMarker struct {
+	definition *marker.Definition
+	transform  CustomMarkerTransform
+}
+
+//nolint:gochecknoglobals //custom markers are registered before any of the manifests are processed
+var customMarkers = struct {
+	sync.RWMutex
+	markers []*customMarker
+}{}
+
+// RegisterCustomMarker registers a custom marker, whose arguments are parsed into the exported
+// fields of the struct type of object, in the same way as those of the built-in markers.  The
+// custom markers are found within the manifests of each workload, along with its field markers.
+func RegisterCustomMarker(name string, object interface{}, transform CustomMarkerTransform) error {
+	if !strings.HasPrefix(name, "+") || strings.HasPrefix(name, builtinMarkerPrefix) || strings.HasSuffix(name, ":") {
+		return fmt.Errorf(
+			"%w; %q must begin with '+', must not end with ':' and may not begin with %q",
+			ErrCustomMarkerInvalidName, name, builtinMarkerPrefix,
+		)
+	}
+
+	if transform == nil {
+		return fmt.Errorf("%w for custom marker %s", ErrCustomMarkerInvalidTransform, name)
+	}
+
+	definition, err := marker.Define(name, object)
+	if err != nil {
+		return fmt.Errorf("%w for custom marker %s; %s", ErrCustomMarkerInvalidType, name, err)
+	}
+
+	// markers are only parsed along with their arguments, so a marker without any arguments
+	// would never be found within a manifest
+	if len(definition.Fields) == 0 {
+		return fmt.Errorf("%w for custom marker %s; must have at least one argument", ErrCustomMarkerInvalidType, name)
+	}
+
+	customMarkers.Lock()
+	defer customMarkers.Unlock()
+
+	for _, existing := range customMarkers.markers {
+		if existing.definition.Name == name {
+			return fmt.Errorf("%w; %s", ErrCustomMarkerDuplicate, name)
+		}
+	}
+
+	customMarkers.markers = append(customMarkers.markers, &customMarker{
+		definition: definition,
+		transform:  transform,
+	})
+
+	return nil
+}
+
+// defineCustomMarkers will add each of the registered custom markers to a registry of markers.
+func defineCustomMarkers(registry *marker.Registry) error {
+	customMarkers.RLock()
+	defer customMarkers.RUnlock()
+
+	for _, custom := range customMarkers.markers {
+		registry.Add(custom.definition)
+	}
+
+	return nil
+}
+
+// transformCustom calls the transform of the custom marker which a result was found for, if any.
+func transformCustom(result *inspect.YAMLResult) error {
+	// markers which could not be parsed have already been reported
+	if _, ok := result.Object.(error); ok {
+		return nil
+	}
+
+	customMarkers.RLock()
+	defer customMarkers.RUnlock()
+
+	for _, custom := range customMarkers.markers {
+		if custom.definition.Name != result.MarkerName {
+			continue
+		}
+
+		if err := custom.transform(result.Object, result.MarkerText, result.Nodes); err != nil {
+			return fmt.Errorf("%w; error transforming custom marker %s", err, custom.definition.Name)
+		}
+
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+type testCustomMarker struct {
+	Key   string
+	Value *string
+}
+
+func TestRegisterCustomMarker(t *testing.T) {
+	t.Parallel()
+
+	noop := func(object interface{}, markerText string, nodes []*yaml.Node) error { return nil }
+
+	// register a marker up front so that registering it again may be tested
+	assert.NoError(t, RegisterCustomMarker("+test:register:duplicate", testCustomMarker{}, noop))
+
+	type args struct {
+		name      string
+		object    interface{}
+		transform CustomMarkerTransform
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ensure valid custom marker is registered",
+			args: args{
+				name:      "+test:register:valid",
+				object:    testCustomMarker{},
+				transform: noop,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ensure custom marker without leading plus returns error",
+			args: args{
+				name:      "test:register:invalid",
+				object:    testCustomMarker{},
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerInvalidName,
+		},
+		{
+			name: "ensure custom marker with trailing colon returns error",
+			args: args{
+				name:      "+test:register:invalid:",
+				object:    testCustomMarker{},
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerInvalidName,
+		},
+		{
+			name: "ensure custom marker with built-in prefix returns error",
+			args: args{
+				name:      "+operator-builder:labels",
+				object:    testCustomMarker{},
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerInvalidName,
+		},
+		{
+			name: "ensure custom marker without transform returns error",
+			args: args{
+				name:      "+test:register:notransform",
+				object:    testCustomMarker{},
+				transform: nil,
+			},
+			wantErr: ErrCustomMarkerInvalidTransform,
+		},
+		{
+			name: "ensure custom marker with non-struct type returns error",
+			args: args{
+				name:      "+test:register:string",
+				object:    "",
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerInvalidType,
+		},
+		{
+			name: "ensure custom marker without arguments returns error",
+			args: args{
+				name:      "+test:register:empty",
+				object:    struct{}{},
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerInvalidType,
+		},
+		{
+			name: "ensure custom marker registered twice returns error",
+			args: args{
+				name:      "+test:register:duplicate",
+				object:    testCustomMarker{},
+				transform: noop,
+			},
+			wantErr: ErrCustomMarkerDuplicate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := RegisterCustomMarker(tt.args.name, tt.args.object, tt.args.transform)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInspectForYAML_customMarker(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test transform error")
+
+	// add the key and value of the marker as a label to the metadata which it marks
+	assert.NoError(t, RegisterCustomMarker(
+		"+test:inspect:label",
+		testCustomMarker{},
+		func(object interface{}, markerText string, nodes []*yaml.Node) error {
+			custom, ok := object.(testCustomMarker)
+			if !ok {
+				return errTest
+			}
+
+			value := "true"
+			if custom.Value != nil {
+				value = *custom.Value
+			}
+
+			metadata := nodes[len(nodes)-1]
+			metadata.Content = append(metadata.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "labels"},
+				&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Value: custom.Key},
+					{Kind: yaml.ScalarNode, Value: value},
+				}},
+			)
+
+			return nil
+		},
+	))
+
+	assert.NoError(t, RegisterCustomMarker(
+		"+test:inspect:fail",
+		testCustomMarker{},
+		func(object interface{}, markerText string, nodes []*yaml.Node) error {
+			return errTest
+		},
+	))
+
+	tests := []struct {
+		name        string
+		manifest    string
+		markerTypes []MarkerType
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "ensure custom marker transforms the marked nodes",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:label:key=team,value=platform
+metadata:
+  name: test
+`,
+			markerTypes: []MarkerType{CustomMarkerType},
+			want: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:label:key=team,value=platform
+metadata:
+    name: test
+    labels:
+        team: platform
+`,
+		},
+		{
+			name: "ensure custom marker is ignored when custom markers are not inspected",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:label:key=team,value=platform
+metadata:
+  name: test
+`,
+			markerTypes: []MarkerType{FieldMarkerType},
+			want: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:label:key=team,value=platform
+metadata:
+    name: test
+`,
+		},
+		{
+			name: "ensure custom marker with missing argument returns error",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:label:value=platform
+metadata:
+  name: test
+`,
+			markerTypes: []MarkerType{CustomMarkerType},
+			wantErr:     marker.ErrMissingArguments,
+		},
+		{
+			name: "ensure custom marker transform error is returned",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+# +test:inspect:fail:key=team
+metadata:
+  name: test
+`,
+			markerTypes: []MarkerType{CustomMarkerType},
+			wantErr:     errTest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nodes, _, err := InspectForYAML([]byte(tt.manifest), tt.markerTypes...)
+			if tt.wantErr != nil {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr.Error())
+				}
+
+				return
+			}
+
+			if !assert.NoError(t, err) || !assert.Len(t, nodes, 1) {
+				return
+			}
+
+			got, err := yaml.Marshal(nodes[0])
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestInspectForYAML_customMarkerName(t *testing.T) {
+	t.Parallel()
+
+	// each marker labels the metadata which it marks with its own name, so that the marker whose
+	// transform was called may be told apart from those whose names it begins with
+	for _, name := range []string{"+test:name:foo", "+test:name:foobar", "+test:name:foo:bar"} {
+		name := name
+
+		assert.NoError(t, RegisterCustomMarker(
+			name,
+			testCustomMarker{},
+			func(object interface{}, markerText string, nodes []*yaml.Node) error {
+				metadata := nodes[len(nodes)-1]
+				metadata.Content = append(metadata.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: "labels"},
+					&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Value: "marker"},
+						{Kind: yaml.ScalarNode, Value: name},
+					}},
+				)
+
+				return nil
+			},
+		))
+	}
+
+	tests := []struct {
+		name   string
+		marker string
+		want   string
+	}{
+		{
+			name:   "ensure custom marker transform is called for its own name",
+			marker: "+test:name:foo:key=team",
+			want:   "+test:name:foo",
+		},
+		{
+			name:   "ensure custom marker transform is not called for a name which begins with its own",
+			marker: "+test:name:foobar:key=team",
+			want:   "+test:name:foobar",
+		},
+		{
+			name:   "ensure custom marker transform is not called for a name which is scoped within its own",
+			marker: "+test:name:foo:bar:key=team",
+			want:   "+test:name:foo:bar",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := "apiVersion: v1\nkind: ConfigMap\n# " + tt.marker + "\nmetadata:\n  name: test\n"
+
+			nodes, _, err := InspectForYAML([]byte(manifest), CustomMarkerType)
+			if !assert.NoError(t, err) || !assert.Len(t, nodes, 1) {
+				return
+			}
+
+			got, err := yaml.Marshal(nodes[0])
+			if assert.NoError(t, err) {
+				assert.Contains(t, string(got), "labels:\n        marker: "+tt.want+"\n")
+			}
+		})
+	}
+}
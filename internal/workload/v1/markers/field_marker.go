@@ -30,11 +30,32 @@ type FieldMarker struct {
 	Description *string
 	Default     interface{} `marker:",optional"`
 	Replace     *string
+	Merge       *bool
+	Optional    *bool
+	Sensitive   *bool
+	Target      FieldTarget `marker:",optional"`
+
+	// validation inputs from the marker itself
+	Minimum   interface{} `marker:",optional"`
+	Maximum   interface{} `marker:",optional"`
+	MinLength *int
+	MaxLength *int
+	Pattern   *string
+	Enum      *string
+	Immutable *bool
+	Rule      *string
+
+	// print column inputs from the marker itself
+	PrintColumn         *bool
+	PrintColumnName     *string
+	PrintColumnPriority *int
 
 	// other values which we use to pass information
-	forCollection bool
-	sourceCodeVar string
-	originalValue interface{}
+	forCollection     bool
+	sourceCodeVar     string
+	originalValue     interface{}
+	validationMarkers []string
+	printColumn       *PrintColumn
 }
 
 //nolint:gocritic //needed to implement string interface
@@ -90,6 +111,34 @@ func (fm *FieldMarker) GetReplaceText() string {
 	return *fm.Replace
 }
 
+func (fm *FieldMarker) GetMerge() bool {
+	if fm.Merge == nil {
+		return false
+	}
+
+	return *fm.Merge
+}
+
+func (fm *FieldMarker) IsOptional() bool {
+	if fm.Optional == nil {
+		return false
+	}
+
+	return *fm.Optional
+}
+
+func (fm *FieldMarker) IsSensitive() bool {
+	if fm.Sensitive == nil {
+		return false
+	}
+
+	return *fm.Sensitive
+}
+
+func (fm *FieldMarker) GetTarget() FieldTarget {
+	return fm.Target
+}
+
 func (fm *FieldMarker) GetSpecPrefix() string {
 	return FieldSpecPrefix
 }
@@ -102,6 +151,14 @@ func (fm *FieldMarker) GetSourceCodeVariable() string {
 	return fm.sourceCodeVar
 }
 
+func (fm *FieldMarker) GetValidationMarkers() []string {
+	return fm.validationMarkers
+}
+
+func (fm *FieldMarker) GetPrintColumn() *PrintColumn {
+	return fm.printColumn
+}
+
 func (fm *FieldMarker) IsCollectionFieldMarker() bool {
 	return false
 }
@@ -115,6 +172,12 @@ func (fm *FieldMarker) IsForCollection() bool {
 }
 
 func (fm *FieldMarker) SetOriginalValue(value string) {
+	if fm.IsSensitive() {
+		fm.originalValue = sensitiveSampleValue
+
+		return
+	}
+
 	if fm.GetReplaceText() != "" {
 		fm.originalValue = fm.GetReplaceText()
 
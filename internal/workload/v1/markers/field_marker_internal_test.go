@@ -315,6 +315,94 @@ func TestFieldMarker_GetReplaceText(t *testing.T) {
 	}
 }
 
+func TestFieldMarker_GetMerge(t *testing.T) {
+	t.Parallel()
+
+	fmMerge := true
+
+	type fields struct {
+		Merge *bool
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "ensure field merge returns as expected",
+			fields: fields{
+				Merge: &fmMerge,
+			},
+			want: true,
+		},
+		{
+			name: "ensure field merge with empty value returns as expected",
+			fields: fields{
+				Merge: nil,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := &FieldMarker{
+				Merge: tt.fields.Merge,
+			}
+			if got := fm.GetMerge(); got != tt.want {
+				t.Errorf("FieldMarker.GetMerge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldMarker_IsOptional(t *testing.T) {
+	t.Parallel()
+
+	fmOptional := true
+
+	type fields struct {
+		Optional *bool
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "ensure field optional returns as expected",
+			fields: fields{
+				Optional: &fmOptional,
+			},
+			want: true,
+		},
+		{
+			name: "ensure field optional with empty value returns as expected",
+			fields: fields{
+				Optional: nil,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fm := &FieldMarker{
+				Optional: tt.fields.Optional,
+			}
+			if got := fm.IsOptional(); got != tt.want {
+				t.Errorf("FieldMarker.IsOptional() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFieldMarker_GetSpecPrefix(t *testing.T) {
 	t.Parallel()
 
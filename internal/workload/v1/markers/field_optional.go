@@ -0,0 +1,161 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrFieldMarkerInvalidOptional = errors.New("field marker optional argument is invalid")
+
+// optionalTag is the tag given to the value of an optional field within a manifest.  Values
+// with this tag are removed from a manifest prior to generating its source code, and are
+// instead set on the generated object only when the field has been set by the user.
+const optionalTag = "!!optional"
+
+// optionalCode is the source code used to conditionally set an optional field on an object.
+// The value is only evaluated once, as it may be the source code which converts a field.
+const optionalCode = `
+%s	if value := %s; value != nil {
+		%s[%q] = %s
+	}
+`
+
+// optionalField represents an optional field which has been removed from a manifest.
+type optionalField struct {
+	// parent is the source code expression of the mapping which holds the field
+	parent  string
+	key     string
+	value   string
+	comment string
+}
+
+// validateOptional validates that the arguments of an optional marker do not conflict.  An
+// optional field is omitted entirely when unset, so it may not be combined with arguments
+// which always render a value.
+func validateOptional(marker FieldMarkerProcessor) error {
+	switch {
+	case marker.GetDefault() != nil:
+		return fmt.Errorf("%w; optional may not be combined with default", ErrFieldMarkerInvalidOptional)
+	case marker.GetReplaceText() != "":
+		return fmt.Errorf("%w; optional may not be combined with replace", ErrFieldMarkerInvalidOptional)
+	case marker.GetMerge():
+		return fmt.Errorf("%w; optional may not be combined with merge", ErrFieldMarkerInvalidOptional)
+	}
+
+	return nil
+}
+
+// ExtractOptionalFields removes the optional fields from a single manifest and returns the
+// remaining manifest along with the source code which sets each optional field on the object,
+// named by varName, when the field has been set by the user.  The manifest is returned
+// unmodified if it contains no optional fields.
+func ExtractOptionalFields(manifest []byte, varName string) ([]byte, string, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(manifest, &document); err != nil {
+		return nil, "", fmt.Errorf("%w; unable to unmarshal manifest for optional fields", err)
+	}
+
+	if len(document.Content) == 0 {
+		return manifest, "", nil
+	}
+
+	fields := extractOptionalFields(document.Content[0], varName+".Object")
+	if len(fields) == 0 {
+		return manifest, "", nil
+	}
+
+	content, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w; unable to marshal manifest without optional fields", err)
+	}
+
+	var code strings.Builder
+
+	for _, field := range fields {
+		// optional fields which are not nillable are represented as pointers and dereferenced
+		var dereference string
+
+		if strings.HasPrefix(field.value, "*") {
+			dereference = "*"
+		}
+
+		code.WriteString(fmt.Sprintf(
+			optionalCode, field.comment, strings.TrimPrefix(field.value, "*"), field.parent, field.key, dereference+"value",
+		))
+	}
+
+	return content, code.String(), nil
+}
+
+// extractOptionalFields recursively removes the optional fields from a node, given the source
+// code expression which represents the node, and returns the fields which were removed.
+func extractOptionalFields(node *yaml.Node, expression string) []optionalField {
+	var fields []optionalField
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		content := make([]*yaml.Node, 0, len(node.Content))
+
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if value.Tag == optionalTag {
+				fields = append(fields, optionalField{
+					parent:  expression,
+					key:     key.Value,
+					value:   value.Value,
+					comment: optionalComment(key),
+				})
+
+				continue
+			}
+
+			content = append(content, key, value)
+			fields = append(fields, extractOptionalFields(value, fmt.Sprintf("%s[%q]%s", expression, key.Value, typeAssertion(value)))...)
+		}
+
+		node.Content = content
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			fields = append(fields, extractOptionalFields(item, fmt.Sprintf("%s[%d]%s", expression, i, typeAssertion(item)))...)
+		}
+	}
+
+	return fields
+}
+
+// optionalComment returns the head comment of the key of an optional field as source code
+// comments so that the origin of the field is retained within the generated source code.
+func optionalComment(key *yaml.Node) string {
+	var comment strings.Builder
+
+	for _, line := range strings.Split(key.HeadComment, "\n") {
+		if line == "" {
+			continue
+		}
+
+		comment.WriteString(fmt.Sprintf("\t// %s\n", strings.TrimSpace(strings.TrimPrefix(line, "#"))))
+	}
+
+	return comment.String()
+}
+
+// typeAssertion returns the type assertion needed to access the contents of a node within
+// generated source code.
+func typeAssertion(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return ".(map[string]interface{})"
+	case yaml.SequenceNode:
+		return ".([]interface{})"
+	default:
+		return ""
+	}
+}
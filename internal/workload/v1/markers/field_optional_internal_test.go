@@ -0,0 +1,154 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateOptional(t *testing.T) {
+	t.Parallel()
+
+	testOptional := true
+	testReplaceText := "<replace me>"
+
+	tests := []struct {
+		name    string
+		marker  FieldMarkerProcessor
+		wantErr bool
+	}{
+		{
+			name: "optional marker without conflicting arguments",
+			marker: &FieldMarker{
+				Type:     FieldString,
+				Optional: &testOptional,
+			},
+			wantErr: false,
+		},
+		{
+			name: "optional marker with default",
+			marker: &FieldMarker{
+				Type:     FieldString,
+				Default:  "test",
+				Optional: &testOptional,
+			},
+			wantErr: true,
+		},
+		{
+			name: "optional collection marker with replace",
+			marker: &CollectionFieldMarker{
+				Type:     FieldString,
+				Replace:  &testReplaceText,
+				Optional: &testOptional,
+			},
+			wantErr: true,
+		},
+		{
+			name: "optional marker with merge",
+			marker: &FieldMarker{
+				Type:     FieldStringMap,
+				Merge:    &testOptional,
+				Optional: &testOptional,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateOptional(tt.marker); (err != nil) != tt.wantErr {
+				t.Errorf("validateOptional() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		manifest     string
+		wantManifest string
+		wantCode     string
+		wantErr      bool
+	}{
+		{
+			name: "manifest without optional fields is unmodified",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+data:
+  key: !!var parent.Spec.Value
+`,
+			wantManifest: `apiVersion: v1
+kind: ConfigMap
+data:
+  key: !!var parent.Spec.Value
+`,
+			wantCode: "",
+		},
+		{
+			name: "manifest with optional fields",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: !!optional '*parent.Spec.Replicas'
+  template:
+    spec:
+      containers:
+        - name: test
+          # controlled by field: args
+          args: !!optional parent.Spec.Args
+`,
+			wantManifest: `apiVersion: apps/v1
+kind: Deployment
+spec:
+    template:
+        spec:
+            containers:
+                - name: test
+`,
+			wantCode: `
+	if value := parent.Spec.Replicas; value != nil {
+		resourceObj.Object["spec"].(map[string]interface{})["replicas"] = *value
+	}
+
+	// controlled by field: args
+	if value := parent.Spec.Args; value != nil {
+		resourceObj.Object["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].` +
+				`(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})["args"] = value
+	}
+`,
+		},
+		{
+			name:     "invalid manifest returns an error",
+			manifest: "key: [",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotManifest, gotCode, err := ExtractOptionalFields([]byte(tt.manifest), "resourceObj")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExtractOptionalFields() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantManifest, string(gotManifest))
+			assert.Equal(t, tt.wantCode, gotCode)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var ErrFieldMarkerInvalidPrintColumn = errors.New("field marker print column is invalid")
+
+const printColumnMarkerPrefix = "// +kubebuilder:printcolumn"
+
+// PrintColumn represents an additional printer column of a custom resource which is
+// displayed by kubectl when getting the custom resource.
+type PrintColumn struct {
+	Name     string
+	Type     string
+	JSONPath string
+	Priority int
+}
+
+// NewAgePrintColumn returns the print column which displays the age of a custom resource.
+// Kubectl only displays the age of a custom resource by default when no additional print
+// columns are defined, so this column is added alongside any additional print columns.
+func NewAgePrintColumn() *PrintColumn {
+	return &PrintColumn{
+		Name:     "Age",
+		Type:     "date",
+		JSONPath: ".metadata.creationTimestamp",
+	}
+}
+
+// ToMarker will return the print column as a kubebuilder marker in string format.
+func (pc *PrintColumn) ToMarker() string {
+	if pc.Priority > 0 {
+		return fmt.Sprintf("%s:name=%q,type=%s,JSONPath=%q,priority=%d",
+			printColumnMarkerPrefix,
+			pc.Name,
+			pc.Type,
+			pc.JSONPath,
+			pc.Priority,
+		)
+	}
+
+	return fmt.Sprintf("%s:name=%q,type=%s,JSONPath=%q",
+		printColumnMarkerPrefix,
+		pc.Name,
+		pc.Type,
+		pc.JSONPath,
+	)
+}
+
+// getPrintColumn returns the print column which is requested by the print column arguments
+// of a field marker.  A nil print column is returned if no print column is requested.
+func getPrintColumn(marker *FieldMarker) (*PrintColumn, error) {
+	if marker.PrintColumn == nil || !*marker.PrintColumn {
+		if marker.PrintColumnName != nil || marker.PrintColumnPriority != nil {
+			return nil, fmt.Errorf(
+				"%w; printColumnName and printColumnPriority require printColumn",
+				ErrFieldMarkerInvalidPrintColumn,
+			)
+		}
+
+		return nil, nil
+	}
+
+	columnType := marker.Type.PrintColumnType()
+	if columnType == "" {
+		return nil, fmt.Errorf("%w; print column is unsupported for field type %s", ErrFieldMarkerInvalidPrintColumn, marker.Type)
+	}
+
+	printColumn := &PrintColumn{
+		Name:     printColumnName(marker.Name),
+		Type:     columnType,
+		JSONPath: fmt.Sprintf(".spec.%s", marker.Name),
+	}
+
+	if marker.PrintColumnName != nil {
+		if *marker.PrintColumnName == "" {
+			return nil, fmt.Errorf("%w; printColumnName must not be empty", ErrFieldMarkerInvalidPrintColumn)
+		}
+
+		printColumn.Name = *marker.PrintColumnName
+	}
+
+	if marker.PrintColumnPriority != nil {
+		if *marker.PrintColumnPriority < 0 {
+			return nil, fmt.Errorf("%w; printColumnPriority must not be negative", ErrFieldMarkerInvalidPrintColumn)
+		}
+
+		printColumn.Priority = *marker.PrintColumnPriority
+	}
+
+	return printColumn, nil
+}
+
+// printColumnName returns the default name of a print column given the name of a field
+// (e.g. webStore.replicas is displayed as Web Store Replicas).
+func printColumnName(fieldName string) string {
+	var name strings.Builder
+
+	for _, part := range strings.Split(fieldName, ".") {
+		for i, r := range part {
+			switch {
+			case i == 0:
+				if name.Len() > 0 {
+					name.WriteRune(' ')
+				}
+
+				name.WriteRune(unicode.ToUpper(r))
+			case unicode.IsUpper(r):
+				name.WriteRune(' ')
+				name.WriteRune(r)
+			default:
+				name.WriteRune(r)
+			}
+		}
+	}
+
+	return name.String()
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getPrintColumn(t *testing.T) {
+	t.Parallel()
+
+	testTrue := true
+	testFalse := false
+	testName := "Replicas"
+	testEmptyName := ""
+	testPriority := 1
+	testNegativePriority := -1
+
+	tests := []struct {
+		name    string
+		marker  *FieldMarker
+		want    *PrintColumn
+		wantErr bool
+	}{
+		{
+			name: "field marker without print column returns no print column",
+			marker: &FieldMarker{
+				Name: "webStoreReplicas",
+				Type: FieldInt,
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "field marker with print column disabled returns no print column",
+			marker: &FieldMarker{
+				Name:        "webStoreReplicas",
+				Type:        FieldInt,
+				PrintColumn: &testFalse,
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "field marker with print column returns default print column",
+			marker: &FieldMarker{
+				Name:        "webStore.replicas",
+				Type:        FieldInt,
+				PrintColumn: &testTrue,
+			},
+			want: &PrintColumn{
+				Name:     "Web Store Replicas",
+				Type:     "integer",
+				JSONPath: ".spec.webStore.replicas",
+			},
+			wantErr: false,
+		},
+		{
+			name: "field marker with print column name and priority returns print column",
+			marker: &FieldMarker{
+				Name:                "webStoreReplicas",
+				Type:                FieldInt32,
+				PrintColumn:         &testTrue,
+				PrintColumnName:     &testName,
+				PrintColumnPriority: &testPriority,
+			},
+			want: &PrintColumn{
+				Name:     "Replicas",
+				Type:     "integer",
+				JSONPath: ".spec.webStoreReplicas",
+				Priority: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "field marker with print column name but without print column returns error",
+			marker: &FieldMarker{
+				Name:            "webStoreReplicas",
+				Type:            FieldInt,
+				PrintColumnName: &testName,
+			},
+			wantErr: true,
+		},
+		{
+			name: "field marker with empty print column name returns error",
+			marker: &FieldMarker{
+				Name:            "webStoreReplicas",
+				Type:            FieldInt,
+				PrintColumn:     &testTrue,
+				PrintColumnName: &testEmptyName,
+			},
+			wantErr: true,
+		},
+		{
+			name: "field marker with negative print column priority returns error",
+			marker: &FieldMarker{
+				Name:                "webStoreReplicas",
+				Type:                FieldInt,
+				PrintColumn:         &testTrue,
+				PrintColumnPriority: &testNegativePriority,
+			},
+			wantErr: true,
+		},
+		{
+			name: "map field marker with print column returns error",
+			marker: &FieldMarker{
+				Name:        "webStoreLabels",
+				Type:        FieldStringMap,
+				PrintColumn: &testTrue,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := getPrintColumn(tt.marker)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getPrintColumn() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPrintColumn_ToMarker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		printColumn *PrintColumn
+		want        string
+	}{
+		{
+			name: "print column without priority",
+			printColumn: &PrintColumn{
+				Name:     "Created",
+				Type:     "boolean",
+				JSONPath: ".status.created",
+			},
+			want: `// +kubebuilder:printcolumn:name="Created",type=boolean,JSONPath=".status.created"`,
+		},
+		{
+			name: "print column with priority",
+			printColumn: &PrintColumn{
+				Name:     "Web Store Replicas",
+				Type:     "integer",
+				JSONPath: ".spec.webStoreReplicas",
+				Priority: 1,
+			},
+			want: `// +kubebuilder:printcolumn:name="Web Store Replicas",type=integer,JSONPath=".spec.webStoreReplicas",priority=1`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.printColumn.ToMarker())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrFieldMarkerInvalidSensitive = errors.New("field marker sensitive argument is invalid")
+
+const (
+	// SensitiveFieldType is the type of the API field which is generated for a sensitive field
+	// marker.  Rather than holding the value itself, the API field references a key of a secret
+	// which holds the value.
+	SensitiveFieldType FieldType = "corev1.SecretKeySelector"
+
+	// SensitiveSampleSecretName and SensitiveSampleSecretKey are the name and the key of the
+	// secret which is referenced by the sensitive fields of a sample manifest.
+	SensitiveSampleSecretName = "secret-name"
+	SensitiveSampleSecretKey  = "secret-key"
+
+	// sensitiveSampleValue is the sample value of a sensitive field.  The value found within
+	// the manifest is never used as a sample so that it is not leaked into the samples.
+	sensitiveSampleValue = "{name: " + SensitiveSampleSecretName + ", key: " + SensitiveSampleSecretKey + "}"
+
+	sensitivePlaceholderPrefix = "<sensitive:"
+	sensitivePlaceholderSuffix = ">"
+)
+
+// SensitiveField represents a field whose value is resolved from a secret by the controller
+// rather than being stored within the custom resource.  The generated child resources hold a
+// placeholder in place of the value until the placeholder is resolved.  The placeholder is
+// referenced by a constant within the source code, as the object code generator escapes any
+// quotes which are substituted into a string value.
+type SensitiveField struct {
+	Name       string
+	Collection bool
+}
+
+// NewSensitiveField returns the sensitive field which is requested by a field marker or a
+// collection field marker.
+func NewSensitiveField(marker FieldMarkerProcessor) *SensitiveField {
+	return &SensitiveField{
+		Name:       marker.GetName(),
+		Collection: marker.IsCollectionFieldMarker(),
+	}
+}
+
+// GetPlaceholder returns the placeholder which is rendered in place of the value of a
+// sensitive field (e.g. <sensitive:password> or <sensitive:collection.password>).
+func (sf *SensitiveField) GetPlaceholder() string {
+	name := sf.Name
+
+	if sf.Collection {
+		name = templateCollectionPrefix + name
+	}
+
+	return sensitivePlaceholderPrefix + name + sensitivePlaceholderSuffix
+}
+
+// GetVariableName returns the name of the constant which holds the placeholder of a sensitive
+// field within the source code (e.g. sensitiveDatabasePassword).
+func (sf *SensitiveField) GetVariableName() string {
+	name := strings.ReplaceAll(strings.Title(sf.Name), ".", "")
+
+	if sf.Collection {
+		return "sensitiveCollection" + name
+	}
+
+	return "sensitive" + name
+}
+
+// GetFieldName returns the name of the field, relative to the spec, as it is represented in
+// the source code.
+func (sf *SensitiveField) GetFieldName() string {
+	return strings.Title(sf.Name)
+}
+
+// validateSensitive validates that the arguments of a sensitive marker do not conflict.  The
+// API field of a sensitive marker is a reference to a secret, so it may only replace string
+// values and may not be combined with arguments which apply to the value itself.
+func validateSensitive(marker *FieldMarker) error {
+	if marker.Sensitive == nil || !*marker.Sensitive {
+		return nil
+	}
+
+	var conflict string
+
+	switch {
+	case marker.Type != FieldString:
+		return fmt.Errorf("%w; sensitive is unsupported for field type %s", ErrFieldMarkerInvalidSensitive, marker.Type)
+	case marker.Default != nil:
+		conflict = "default"
+	case marker.Optional != nil:
+		conflict = "optional"
+	case marker.Merge != nil:
+		conflict = "merge"
+	case marker.Minimum != nil, marker.Maximum != nil:
+		conflict = "minimum or maximum"
+	case marker.MinLength != nil, marker.MaxLength != nil:
+		conflict = "minLength or maxLength"
+	case marker.Pattern != nil:
+		conflict = "pattern"
+	case marker.Enum != nil:
+		conflict = "enum"
+	case marker.Rule != nil:
+		conflict = "rule"
+	case marker.PrintColumn != nil:
+		conflict = "printColumn"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("%w; sensitive may not be combined with %s", ErrFieldMarkerInvalidSensitive, conflict)
+}
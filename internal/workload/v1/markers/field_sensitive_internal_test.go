@@ -0,0 +1,199 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_validateSensitive(t *testing.T) {
+	t.Parallel()
+
+	testSensitive := true
+	testImmutable := true
+	testMinLength := 8
+	testPrintColumn := true
+
+	tests := []struct {
+		name    string
+		marker  *FieldMarker
+		wantErr bool
+	}{
+		{
+			name: "sensitive marker without conflicting arguments",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Sensitive: &testSensitive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sensitive marker which is immutable",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Sensitive: &testSensitive,
+				Immutable: &testImmutable,
+			},
+			wantErr: false,
+		},
+		{
+			name: "non-sensitive marker with an unsupported type",
+			marker: &FieldMarker{
+				Type: FieldInt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sensitive marker with an unsupported type",
+			marker: &FieldMarker{
+				Type:      FieldInt,
+				Sensitive: &testSensitive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sensitive marker with default",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Default:   "password",
+				Sensitive: &testSensitive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sensitive marker with minLength",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				MinLength: &testMinLength,
+				Sensitive: &testSensitive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sensitive marker with printColumn",
+			marker: &FieldMarker{
+				Type:        FieldString,
+				PrintColumn: &testPrintColumn,
+				Sensitive:   &testSensitive,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateSensitive(tt.marker); (err != nil) != tt.wantErr {
+				t.Errorf("validateSensitive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSensitiveField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		field            *SensitiveField
+		wantPlaceholder  string
+		wantVariableName string
+		wantFieldName    string
+	}{
+		{
+			name:             "sensitive field",
+			field:            &SensitiveField{Name: "database.password"},
+			wantPlaceholder:  "<sensitive:database.password>",
+			wantVariableName: "sensitiveDatabasePassword",
+			wantFieldName:    "Database.Password",
+		},
+		{
+			name:             "sensitive collection field",
+			field:            &SensitiveField{Name: "database.password", Collection: true},
+			wantPlaceholder:  "<sensitive:collection.database.password>",
+			wantVariableName: "sensitiveCollectionDatabasePassword",
+			wantFieldName:    "Database.Password",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantPlaceholder, tt.field.GetPlaceholder())
+			assert.Equal(t, tt.wantVariableName, tt.field.GetVariableName())
+			assert.Equal(t, tt.wantFieldName, tt.field.GetFieldName())
+		})
+	}
+}
+
+func Test_setValue_sensitive(t *testing.T) {
+	t.Parallel()
+
+	testSensitive := true
+	testReplaceText := "secret"
+
+	tests := []struct {
+		name      string
+		marker    FieldMarkerProcessor
+		value     *yaml.Node
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name: "sensitive field marker",
+			marker: &FieldMarker{
+				Name:          "password",
+				Type:          FieldString,
+				Sensitive:     &testSensitive,
+				sourceCodeVar: "sensitivePassword",
+			},
+			value:     &yaml.Node{Kind: yaml.ScalarNode, Value: "secret"},
+			wantValue: "sensitivePassword",
+		},
+		{
+			name: "sensitive collection field marker with replace",
+			marker: &CollectionFieldMarker{
+				Name:          "password",
+				Type:          FieldString,
+				Replace:       &testReplaceText,
+				Sensitive:     &testSensitive,
+				sourceCodeVar: "sensitiveCollectionPassword",
+			},
+			value:     &yaml.Node{Kind: yaml.ScalarNode, Value: "user:secret"},
+			wantValue: "user:!!start sensitiveCollectionPassword !!end",
+		},
+		{
+			name: "sensitive field marker with a non-scalar value",
+			marker: &FieldMarker{
+				Name:      "password",
+				Type:      FieldString,
+				Sensitive: &testSensitive,
+			},
+			value:   &yaml.Node{Kind: yaml.MappingNode},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := setValue(tt.marker, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("setValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantValue, tt.value.Value)
+			assert.Equal(t, sensitiveSampleValue, tt.marker.GetOriginalValue())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrFieldMarkerInvalidTarget = errors.New("field marker target is invalid")
+
+// FieldTarget defines the node of a mapping pair which is controlled by a field marker.
+type FieldTarget string
+
+const (
+	FieldTargetValue FieldTarget = "value"
+	FieldTargetKey   FieldTarget = "key"
+)
+
+// keyVariableStart and keyVariableEnd are the tags which surround a variable within a
+// key.  Unlike values, the object code generator does not substitute variables within
+// keys, so they are substituted within the generated source code instead.
+const (
+	keyVariableStart = "!!start "
+	keyVariableEnd   = " !!end"
+)
+
+// UnmarshalMarkerArg will convert the target argument within a field or collection
+// field marker into its underlying FieldTarget object.
+func (t *FieldTarget) UnmarshalMarkerArg(in string) error {
+	switch target := FieldTarget(in); target {
+	case FieldTargetValue, FieldTargetKey:
+		*t = target
+
+		return nil
+	default:
+		return fmt.Errorf("%w; %s must be one of [%s, %s]", ErrFieldMarkerInvalidTarget, in, FieldTargetValue, FieldTargetKey)
+	}
+}
+
+// String simply returns a FieldTarget in string format.
+func (t FieldTarget) String() string {
+	if t == "" {
+		return string(FieldTargetValue)
+	}
+
+	return string(t)
+}
+
+// validateKeyTarget validates that the arguments of a marker which targets a key do not
+// conflict.  A key is always rendered as a string, so the field must be a scalar which may
+// be converted into a string.
+func validateKeyTarget(marker FieldMarkerProcessor) error {
+	fieldType := marker.GetFieldType()
+
+	switch {
+	case fieldType.IsArray() || fieldType.IsMap() || fieldType.IsKubernetesType():
+		return fmt.Errorf("%w; target=key is unsupported for field type %s", ErrFieldMarkerInvalidTarget, fieldType)
+	case marker.IsOptional():
+		return fmt.Errorf("%w; target=key may not be combined with optional", ErrFieldMarkerInvalidTarget)
+	case marker.GetMerge():
+		return fmt.Errorf("%w; target=key may not be combined with merge", ErrFieldMarkerInvalidTarget)
+	case marker.IsSensitive():
+		return fmt.Errorf("%w; target=key may not be combined with sensitive", ErrFieldMarkerInvalidTarget)
+	}
+
+	return nil
+}
+
+// setKey will set the key of a mapping pair appropriately.  This is based on whether the
+// marker has requested replacement text.  The value of the mapping pair is left as is.
+func setKey(marker FieldMarkerProcessor, key, value *yaml.Node) error {
+	if key == value {
+		return fmt.Errorf("%w; target=key is only supported for a mapping key", ErrFieldMarkerInvalidTarget)
+	}
+
+	if err := validateKeyTarget(marker); err != nil {
+		return err
+	}
+
+	marker.SetOriginalValue(key.Value)
+
+	sourceCode := getSourceCodeFieldVariable(marker)
+
+	if markerReplaceText := marker.GetReplaceText(); markerReplaceText != "" {
+		re, err := regexp.Compile(markerReplaceText)
+		if err != nil {
+			return fmt.Errorf("unable to convert %s to regex, %w", markerReplaceText, err)
+		}
+
+		key.Value = re.ReplaceAllString(key.Value, sourceCode)
+	} else {
+		key.Value = sourceCode
+	}
+
+	key.Tag = "!!str"
+
+	return nil
+}
+
+// ExpandKeyVariables substitutes the variables within the keys of generated source code so that
+// the keys are built from the fields of the custom resource.  Each variable is concatenated with
+// any surrounding literal text of the key (e.g. "env-!!start parent.Spec.Env !!end" is expanded
+// to "env-" + parent.Spec.Env + "").
+func ExpandKeyVariables(sourceCode string) string {
+	if !strings.Contains(sourceCode, keyVariableStart) {
+		return sourceCode
+	}
+
+	sourceCode = strings.ReplaceAll(sourceCode, keyVariableStart, `" + `)
+
+	return strings.ReplaceAll(sourceCode, keyVariableEnd, ` + "`)
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFieldTarget_UnmarshalMarkerArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    FieldTarget
+		wantErr bool
+	}{
+		{
+			name: "key target",
+			in:   "key",
+			want: FieldTargetKey,
+		},
+		{
+			name: "value target",
+			in:   "value",
+			want: FieldTargetValue,
+		},
+		{
+			name:    "unknown target",
+			in:      "comment",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var target FieldTarget
+			if err := target.UnmarshalMarkerArg(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("FieldTarget.UnmarshalMarkerArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, target)
+		})
+	}
+}
+
+func Test_setKey(t *testing.T) {
+	t.Parallel()
+
+	testOptional := true
+	testReplaceText := "dev"
+	testSensitive := true
+
+	tests := []struct {
+		name     string
+		marker   FieldMarkerProcessor
+		key      *yaml.Node
+		sameNode bool
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "key target",
+			marker: &FieldMarker{
+				Type:          FieldString,
+				sourceCodeVar: "parent.Spec.Environment",
+			},
+			key:  &yaml.Node{Kind: yaml.ScalarNode, Value: "dev"},
+			want: "!!start parent.Spec.Environment !!end",
+		},
+		{
+			name: "key target with replace and an int field",
+			marker: &CollectionFieldMarker{
+				Type:          FieldInt,
+				Replace:       &testReplaceText,
+				sourceCodeVar: "collection.Spec.Shard",
+			},
+			key:  &yaml.Node{Kind: yaml.ScalarNode, Value: "acme.com/dev-shard"},
+			want: "acme.com/!!start strconv.Itoa(collection.Spec.Shard) !!end-shard",
+		},
+		{
+			name: "key target on a node which is not a mapping key",
+			marker: &FieldMarker{
+				Type: FieldString,
+			},
+			key:      &yaml.Node{Kind: yaml.ScalarNode, Value: "dev"},
+			sameNode: true,
+			wantErr:  true,
+		},
+		{
+			name: "key target with a map field",
+			marker: &FieldMarker{
+				Type: FieldStringMap,
+			},
+			key:     &yaml.Node{Kind: yaml.ScalarNode, Value: "dev"},
+			wantErr: true,
+		},
+		{
+			name: "key target with optional",
+			marker: &FieldMarker{
+				Type:     FieldString,
+				Optional: &testOptional,
+			},
+			key:     &yaml.Node{Kind: yaml.ScalarNode, Value: "dev"},
+			wantErr: true,
+		},
+		{
+			name: "key target with sensitive",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Sensitive: &testSensitive,
+			},
+			key:     &yaml.Node{Kind: yaml.ScalarNode, Value: "dev"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			value := &yaml.Node{Kind: yaml.ScalarNode, Value: "true"}
+			if tt.sameNode {
+				value = tt.key
+			}
+
+			if err := setKey(tt.marker, tt.key, value); (err != nil) != tt.wantErr {
+				t.Errorf("setKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want, tt.key.Value)
+			assert.Equal(t, "true", value.Value)
+		})
+	}
+}
+
+func TestInspectForYAML_keyTarget(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+kind: ConfigMap
+data:
+  # +operator-builder:field:name=environment,type=string,default="dev",target=key
+  dev: "true"
+`
+
+	nodes, results, err := InspectForYAML([]byte(manifest), FieldMarkerType)
+	if err != nil {
+		t.Fatalf("InspectForYAML() error = %v", err)
+	}
+
+	if !assert.Len(t, results, 1) {
+		return
+	}
+
+	marker, ok := results[0].Object.(*FieldMarker)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, FieldTargetKey, marker.GetTarget())
+
+	content, err := yaml.Marshal(nodes[0])
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+
+	assert.Contains(t, string(content), "!!start parent.Spec.Environment !!end")
+	assert.Contains(t, string(content), `"true"`)
+}
+
+func TestExpandKeyVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sourceCode string
+		want       string
+	}{
+		{
+			name:       "source code with a key variable",
+			sourceCode: `"!!start parent.Spec.Environment !!end": "true",`,
+			want:       `"" + parent.Spec.Environment + "": "true",`,
+		},
+		{
+			name:       "source code with a key variable and literal text",
+			sourceCode: `"acme.com/!!start parent.Spec.Provider !!end-region": "us-east-1",`,
+			want:       `"acme.com/" + parent.Spec.Provider + "-region": "us-east-1",`,
+		},
+		{
+			name:       "source code without a key variable",
+			sourceCode: `"provider": parent.Spec.Provider,`,
+			want:       `"provider": parent.Spec.Provider,`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExpandKeyVariables(tt.sourceCode))
+		})
+	}
+}
@@ -6,54 +6,258 @@ package markers
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 )
 
 var ErrUnableToParseFieldType = errors.New("unable to parse field")
 
 // FieldType defines the types of fields for a field marker that are accepted
-// during parsing of a manifest.
-type FieldType int
+// during parsing of a manifest.  The value of a FieldType is the go type which
+// is used to represent the field within the API spec.
+type FieldType string
 
 const (
-	FieldUnknownType FieldType = iota
-	FieldString
-	FieldInt
-	FieldBool
-	FieldStruct
+	FieldUnknownType FieldType = ""
+	FieldString      FieldType = "string"
+	FieldInt         FieldType = "int"
+	FieldInt32       FieldType = "int32"
+	FieldInt64       FieldType = "int64"
+	FieldFloat32     FieldType = "float32"
+	FieldFloat64     FieldType = "float64"
+	FieldBool        FieldType = "bool"
+	FieldStringMap   FieldType = "map[string]string"
+	FieldStruct      FieldType = "struct"
 )
 
+const (
+	fieldArrayPrefix      = "[]"
+	fieldPackageSeparator = "."
+)
+
+// mapConversionCode is the source code used to copy a map field into a map which may be
+// stored within an unstructured object.
+const mapConversionCode = `func() map[string]interface{} {
+	if %[1]s == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(%[1]s))
+
+	for key, value := range %[1]s {
+		values[key] = value
+	}
+
+	return values
+}()`
+
+// arrayConversionCode is the source code used to copy an array field into a slice which may
+// be stored within an unstructured object.
+const arrayConversionCode = `func() []interface{} {
+	if %[1]s == nil {
+		return nil
+	}
+
+	values := make([]interface{}, len(%[1]s))
+
+	for i, value := range %[1]s {
+		values[i] = %[2]s
+	}
+
+	return values
+}()`
+
+// kubernetesTypePackages returns the packages, keyed by their import alias, from which
+// upstream Kubernetes types may be requested by a field marker (e.g. corev1.Toleration).
+func kubernetesTypePackages() map[string]string {
+	return map[string]string{
+		"appsv1":       "k8s.io/api/apps/v1",
+		"batchv1":      "k8s.io/api/batch/v1",
+		"corev1":       "k8s.io/api/core/v1",
+		"metav1":       "k8s.io/apimachinery/pkg/apis/meta/v1",
+		"networkingv1": "k8s.io/api/networking/v1",
+		"policyv1":     "k8s.io/api/policy/v1",
+		"rbacv1":       "k8s.io/api/rbac/v1",
+	}
+}
+
+// scalarFieldTypes returns the field types which may be requested by a field
+// marker, either on their own or as the element type of an array.
+func scalarFieldTypes() []FieldType {
+	return []FieldType{
+		FieldString,
+		FieldInt,
+		FieldInt32,
+		FieldInt64,
+		FieldFloat32,
+		FieldFloat64,
+		FieldBool,
+	}
+}
+
 // UnmarshalMarkerArg will convert the type argument within a field or collection
 // field marker into its underlying FieldType object.
 func (f *FieldType) UnmarshalMarkerArg(in string) error {
-	types := map[string]FieldType{
-		"":       FieldUnknownType,
-		"string": FieldString,
-		"int":    FieldInt,
-		"bool":   FieldBool,
-	}
-
-	if t, ok := types[in]; ok {
-		if t == FieldUnknownType {
-			return fmt.Errorf("%w, %s into FieldType", ErrUnableToParseFieldType, in)
-		}
+	fieldType := FieldType(in)
 
-		*f = t
+	if fieldType == FieldStringMap || fieldType.IsKubernetesType() {
+		*f = fieldType
 
 		return nil
 	}
 
+	for _, scalarType := range scalarFieldTypes() {
+		if fieldType.ElementType() == scalarType {
+			*f = fieldType
+
+			return nil
+		}
+	}
+
 	return fmt.Errorf("%w, %s into FieldType", ErrUnableToParseFieldType, in)
 }
 
 // String simply returns a FieldType in string format.
 func (f FieldType) String() string {
-	types := map[FieldType]string{
-		FieldUnknownType: "",
-		FieldString:      "string",
-		FieldInt:         "int",
-		FieldBool:        "bool",
-		FieldStruct:      "struct",
+	return string(f)
+}
+
+// IsArray returns whether a FieldType is an array of another FieldType.
+func (f FieldType) IsArray() bool {
+	return strings.HasPrefix(string(f), fieldArrayPrefix)
+}
+
+// IsMap returns whether a FieldType is a map of string keys to string values.
+func (f FieldType) IsMap() bool {
+	return f == FieldStringMap
+}
+
+// IsNillable returns whether a FieldType may be nil without being represented as a
+// pointer.  Optional fields of any other FieldType are represented as pointers.
+func (f FieldType) IsNillable() bool {
+	return f.IsArray() || f.IsMap()
+}
+
+// IsKubernetesType returns whether a FieldType, or the element type of an array FieldType,
+// is an upstream Kubernetes type from one of the known Kubernetes type packages.
+func (f FieldType) IsKubernetesType() bool {
+	if f.ImportPath() == "" {
+		return false
+	}
+
+	typeName := strings.TrimPrefix(string(f.ElementType()), f.ImportAlias()+fieldPackageSeparator)
+	if typeName == "" || strings.Contains(typeName, fieldPackageSeparator) {
+		return false
+	}
+
+	for i, r := range typeName {
+		if (i == 0 && !unicode.IsUpper(r)) || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
 	}
 
-	return types[f]
+	return true
+}
+
+// ImportAlias returns the import alias of the package which defines a FieldType.  An empty
+// string is returned for builtin types.
+func (f FieldType) ImportAlias() string {
+	parts := strings.SplitN(string(f.ElementType()), fieldPackageSeparator, 2)
+	if len(parts) != 2 {
+		return ""
+	}
+
+	return parts[0]
+}
+
+// ImportPath returns the import path of the package which defines a FieldType.  An empty
+// string is returned for builtin types or packages which are not known.
+func (f FieldType) ImportPath() string {
+	return kubernetesTypePackages()[f.ImportAlias()]
+}
+
+// ElementType returns the FieldType of the elements of an array FieldType.  If
+// the FieldType is not an array, the FieldType itself is returned.
+func (f FieldType) ElementType() FieldType {
+	return FieldType(strings.TrimPrefix(string(f), fieldArrayPrefix))
+}
+
+// IsInteger returns whether a FieldType is one of the integer types.
+func (f FieldType) IsInteger() bool {
+	return f == FieldInt || f == FieldInt32 || f == FieldInt64
+}
+
+// IsFloat returns whether a FieldType is one of the floating point types.
+func (f FieldType) IsFloat() bool {
+	return f == FieldFloat32 || f == FieldFloat64
+}
+
+// PrintColumnType returns the type of a print column which displays a field of this
+// FieldType.  An empty string is returned if the FieldType may not be displayed as a
+// print column.
+func (f FieldType) PrintColumnType() string {
+	switch {
+	case f == FieldString:
+		return "string"
+	case f == FieldBool:
+		return "boolean"
+	case f.IsInteger():
+		return "integer"
+	case f.IsFloat():
+		return "number"
+	default:
+		return ""
+	}
+}
+
+// StringConversion returns the source code needed to convert a variable of this
+// FieldType into a string.  This is used when a value is substituted into part
+// of a larger string value (e.g. the replace argument).
+func (f FieldType) StringConversion(variable string) string {
+	switch f {
+	case FieldInt:
+		return fmt.Sprintf("strconv.Itoa(%s)", variable)
+	case FieldInt32:
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", variable)
+	case FieldInt64:
+		return fmt.Sprintf("strconv.FormatInt(%s, 10)", variable)
+	case FieldFloat32:
+		return fmt.Sprintf("strconv.FormatFloat(float64(%s), 'f', -1, 32)", variable)
+	case FieldFloat64:
+		return fmt.Sprintf("strconv.FormatFloat(%s, 'f', -1, 64)", variable)
+	case FieldBool:
+		return fmt.Sprintf("strconv.FormatBool(%s)", variable)
+	default:
+		return variable
+	}
+}
+
+// UnstructuredConversion returns the source code needed to convert a variable of this
+// FieldType into a value which may be stored within an unstructured object.  Unstructured
+// objects may only hold maps of type map[string]interface{}, slices of type []interface{}
+// and 64-bit numbers, so that they may be deep copied, which means that a map or an array
+// of scalars must be copied into a new map or slice.
+func (f FieldType) UnstructuredConversion(variable string) string {
+	switch {
+	case f.IsMap():
+		return fmt.Sprintf(mapConversionCode, variable)
+	case f.IsArray() && !f.IsKubernetesType():
+		return fmt.Sprintf(arrayConversionCode, variable, f.ElementType().elementConversion("value"))
+	default:
+		return variable
+	}
+}
+
+// elementConversion returns the source code needed to convert an element of an array, of
+// which this FieldType is the element type, into a value which may be stored within an
+// unstructured object.
+func (f FieldType) elementConversion(variable string) string {
+	switch {
+	case f.IsInteger() && f != FieldInt64:
+		return fmt.Sprintf("int64(%s)", variable)
+	case f == FieldFloat32:
+		return fmt.Sprintf("float64(%s)", variable)
+	default:
+		return variable
+	}
 }
@@ -4,6 +4,7 @@
 package markers
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,6 +69,123 @@ func TestFieldType_UnmarshalMarkerArg(t *testing.T) {
 			wantErr: false,
 			expect:  FieldBool,
 		},
+		{
+			name: "int32 field type appropriately unmarshaled",
+			f:    FieldInt32,
+			args: args{
+				in: "int32",
+			},
+			wantErr: false,
+			expect:  FieldInt32,
+		},
+		{
+			name: "int64 field type appropriately unmarshaled",
+			f:    FieldInt64,
+			args: args{
+				in: "int64",
+			},
+			wantErr: false,
+			expect:  FieldInt64,
+		},
+		{
+			name: "float32 field type appropriately unmarshaled",
+			f:    FieldFloat32,
+			args: args{
+				in: "float32",
+			},
+			wantErr: false,
+			expect:  FieldFloat32,
+		},
+		{
+			name: "float64 field type appropriately unmarshaled",
+			f:    FieldFloat64,
+			args: args{
+				in: "float64",
+			},
+			wantErr: false,
+			expect:  FieldFloat64,
+		},
+		{
+			name: "array field type appropriately unmarshaled",
+			f:    FieldUnknownType,
+			args: args{
+				in: "[]string",
+			},
+			wantErr: false,
+			expect:  FieldType("[]string"),
+		},
+		{
+			name: "array of invalid field type should return error",
+			f:    FieldUnknownType,
+			args: args{
+				in: "[]fake",
+			},
+			wantErr: true,
+			expect:  FieldUnknownType,
+		},
+		{
+			name: "array of struct field type should return error",
+			f:    FieldUnknownType,
+			args: args{
+				in: "[]struct",
+			},
+			wantErr: true,
+			expect:  FieldUnknownType,
+		},
+		{
+			name: "map field type appropriately unmarshaled",
+			f:    FieldUnknownType,
+			args: args{
+				in: "map[string]string",
+			},
+			wantErr: false,
+			expect:  FieldStringMap,
+		},
+		{
+			name: "array of map field type should return error",
+			f:    FieldUnknownType,
+			args: args{
+				in: "[]map[string]string",
+			},
+			wantErr: true,
+			expect:  FieldUnknownType,
+		},
+		{
+			name: "kubernetes field type appropriately unmarshaled",
+			f:    FieldUnknownType,
+			args: args{
+				in: "corev1.ResourceRequirements",
+			},
+			wantErr: false,
+			expect:  FieldType("corev1.ResourceRequirements"),
+		},
+		{
+			name: "array of kubernetes field type appropriately unmarshaled",
+			f:    FieldUnknownType,
+			args: args{
+				in: "[]corev1.Toleration",
+			},
+			wantErr: false,
+			expect:  FieldType("[]corev1.Toleration"),
+		},
+		{
+			name: "kubernetes field type from unknown package should return error",
+			f:    FieldUnknownType,
+			args: args{
+				in: "fakev1.Toleration",
+			},
+			wantErr: true,
+			expect:  FieldUnknownType,
+		},
+		{
+			name: "unexported kubernetes field type should return error",
+			f:    FieldUnknownType,
+			args: args{
+				in: "corev1.toleration",
+			},
+			wantErr: true,
+			expect:  FieldUnknownType,
+		},
 		{
 			name: "mismatched field type appropriately unmarshaled",
 			f:    FieldUnknownType,
@@ -124,6 +242,26 @@ func TestFieldType_String(t *testing.T) {
 			f:    FieldBool,
 			want: "bool",
 		},
+		{
+			name: "int32 field type returns 'int32'",
+			f:    FieldInt32,
+			want: "int32",
+		},
+		{
+			name: "int64 field type returns 'int64'",
+			f:    FieldInt64,
+			want: "int64",
+		},
+		{
+			name: "float32 field type returns 'float32'",
+			f:    FieldFloat32,
+			want: "float32",
+		},
+		{
+			name: "float64 field type returns 'float64'",
+			f:    FieldFloat64,
+			want: "float64",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -135,3 +273,336 @@ func TestFieldType_String(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldType_IsArray(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		f           FieldType
+		want        bool
+		wantElement FieldType
+	}{
+		{
+			name:        "string field type is not an array",
+			f:           FieldString,
+			want:        false,
+			wantElement: FieldString,
+		},
+		{
+			name:        "array of string field type is an array",
+			f:           FieldType("[]string"),
+			want:        true,
+			wantElement: FieldString,
+		},
+		{
+			name:        "array of int32 field type is an array",
+			f:           FieldType("[]int32"),
+			want:        true,
+			wantElement: FieldInt32,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.IsArray())
+			assert.Equal(t, tt.wantElement, tt.f.ElementType())
+		})
+	}
+}
+
+func TestFieldType_IsNillable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    FieldType
+		want bool
+	}{
+		{
+			name: "string field type is not nillable",
+			f:    FieldString,
+			want: false,
+		},
+		{
+			name: "kubernetes field type is not nillable",
+			f:    FieldType("corev1.ResourceRequirements"),
+			want: false,
+		},
+		{
+			name: "array field type is nillable",
+			f:    FieldType("[]corev1.Toleration"),
+			want: true,
+		},
+		{
+			name: "map field type is nillable",
+			f:    FieldStringMap,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.IsNillable())
+		})
+	}
+}
+
+func TestFieldType_PrintColumnType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    FieldType
+		want string
+	}{
+		{
+			name: "string field type is a string column",
+			f:    FieldString,
+			want: "string",
+		},
+		{
+			name: "bool field type is a boolean column",
+			f:    FieldBool,
+			want: "boolean",
+		},
+		{
+			name: "integer field type is an integer column",
+			f:    FieldInt64,
+			want: "integer",
+		},
+		{
+			name: "float field type is a number column",
+			f:    FieldFloat32,
+			want: "number",
+		},
+		{
+			name: "array field type is not a column",
+			f:    FieldType("[]string"),
+			want: "",
+		},
+		{
+			name: "kubernetes field type is not a column",
+			f:    FieldType("corev1.ResourceRequirements"),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.PrintColumnType())
+		})
+	}
+}
+
+func TestFieldType_IsKubernetesType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		f          FieldType
+		want       bool
+		wantAlias  string
+		wantImport string
+	}{
+		{
+			name:       "string field type is not a kubernetes type",
+			f:          FieldString,
+			want:       false,
+			wantAlias:  "",
+			wantImport: "",
+		},
+		{
+			name:       "core field type is a kubernetes type",
+			f:          FieldType("corev1.ResourceRequirements"),
+			want:       true,
+			wantAlias:  "corev1",
+			wantImport: "k8s.io/api/core/v1",
+		},
+		{
+			name:       "array of apps field type is a kubernetes type",
+			f:          FieldType("[]appsv1.DeploymentCondition"),
+			want:       true,
+			wantAlias:  "appsv1",
+			wantImport: "k8s.io/api/apps/v1",
+		},
+		{
+			name:       "field type from unknown package is not a kubernetes type",
+			f:          FieldType("fake.Type"),
+			want:       false,
+			wantAlias:  "fake",
+			wantImport: "",
+		},
+		{
+			name:       "field type with invalid type name is not a kubernetes type",
+			f:          FieldType("corev1.Resource-Requirements"),
+			want:       false,
+			wantAlias:  "corev1",
+			wantImport: "k8s.io/api/core/v1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.IsKubernetesType())
+			assert.Equal(t, tt.wantAlias, tt.f.ImportAlias())
+			assert.Equal(t, tt.wantImport, tt.f.ImportPath())
+		})
+	}
+}
+
+func TestFieldType_StringConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		f        FieldType
+		variable string
+		want     string
+	}{
+		{
+			name:     "string field type is not converted",
+			f:        FieldString,
+			variable: "parent.Spec.Field",
+			want:     "parent.Spec.Field",
+		},
+		{
+			name:     "int field type is converted",
+			f:        FieldInt,
+			variable: "parent.Spec.Field",
+			want:     "strconv.Itoa(parent.Spec.Field)",
+		},
+		{
+			name:     "int32 field type is converted",
+			f:        FieldInt32,
+			variable: "parent.Spec.Field",
+			want:     "strconv.FormatInt(int64(parent.Spec.Field), 10)",
+		},
+		{
+			name:     "int64 field type is converted",
+			f:        FieldInt64,
+			variable: "parent.Spec.Field",
+			want:     "strconv.FormatInt(parent.Spec.Field, 10)",
+		},
+		{
+			name:     "float32 field type is converted",
+			f:        FieldFloat32,
+			variable: "parent.Spec.Field",
+			want:     "strconv.FormatFloat(float64(parent.Spec.Field), 'f', -1, 32)",
+		},
+		{
+			name:     "float64 field type is converted",
+			f:        FieldFloat64,
+			variable: "parent.Spec.Field",
+			want:     "strconv.FormatFloat(parent.Spec.Field, 'f', -1, 64)",
+		},
+		{
+			name:     "bool field type is converted",
+			f:        FieldBool,
+			variable: "parent.Spec.Field",
+			want:     "strconv.FormatBool(parent.Spec.Field)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.StringConversion(tt.variable))
+		})
+	}
+}
+
+func TestFieldType_UnstructuredConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		f        FieldType
+		variable string
+		want     string
+	}{
+		{
+			name:     "string field type is not converted",
+			f:        FieldString,
+			variable: "parent.Spec.Field",
+			want:     "parent.Spec.Field",
+		},
+		{
+			name:     "map field type is converted",
+			f:        FieldStringMap,
+			variable: "parent.Spec.Field",
+			want: `func() map[string]interface{} {
+	if parent.Spec.Field == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(parent.Spec.Field))
+
+	for key, value := range parent.Spec.Field {
+		values[key] = value
+	}
+
+	return values
+}()`,
+		},
+		{
+			name:     "string array field type is converted",
+			f:        "[]string",
+			variable: "parent.Spec.Field",
+			want: `func() []interface{} {
+	if parent.Spec.Field == nil {
+		return nil
+	}
+
+	values := make([]interface{}, len(parent.Spec.Field))
+
+	for i, value := range parent.Spec.Field {
+		values[i] = value
+	}
+
+	return values
+}()`,
+		},
+		{
+			name:     "int array field type is converted with 64-bit elements",
+			f:        "[]int",
+			variable: "parent.Spec.Field",
+			want:     fmt.Sprintf(arrayConversionCode, "parent.Spec.Field", "int64(value)"),
+		},
+		{
+			name:     "int64 array field type is converted without element conversion",
+			f:        "[]int64",
+			variable: "parent.Spec.Field",
+			want:     fmt.Sprintf(arrayConversionCode, "parent.Spec.Field", "value"),
+		},
+		{
+			name:     "float32 array field type is converted with 64-bit elements",
+			f:        "[]float32",
+			variable: "parent.Spec.Field",
+			want:     fmt.Sprintf(arrayConversionCode, "parent.Spec.Field", "float64(value)"),
+		},
+		{
+			name:     "kubernetes array field type is not converted",
+			f:        "[]corev1.Toleration",
+			variable: "parent.Spec.Field",
+			want:     "parent.Spec.Field",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.f.UnstructuredConversion(tt.variable))
+		})
+	}
+}
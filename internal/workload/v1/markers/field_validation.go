@@ -0,0 +1,143 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrFieldMarkerInvalidValidation = errors.New("field marker validation is invalid")
+
+const validationMarkerPrefix = "+kubebuilder:validation:"
+
+const (
+	immutableRule    = "self == oldSelf"
+	immutableMessage = "Value is immutable"
+)
+
+// getValidationMarkers returns the kubebuilder validation markers which are requested by
+// the validation arguments of a field marker.  An error is returned if a validation argument
+// is unsupported by the type of the field.
+func getValidationMarkers(marker *FieldMarker) ([]string, error) {
+	if err := validateNumericValidation(marker); err != nil {
+		return nil, err
+	}
+
+	if err := validateStringValidation(marker); err != nil {
+		return nil, err
+	}
+
+	var validation []string
+
+	if marker.Minimum != nil {
+		validation = append(validation, fmt.Sprintf("%sMinimum=%v", validationMarkerPrefix, marker.Minimum))
+	}
+
+	if marker.Maximum != nil {
+		validation = append(validation, fmt.Sprintf("%sMaximum=%v", validationMarkerPrefix, marker.Maximum))
+	}
+
+	if marker.MinLength != nil {
+		validation = append(validation, fmt.Sprintf("%sMinLength=%d", validationMarkerPrefix, *marker.MinLength))
+	}
+
+	if marker.MaxLength != nil {
+		validation = append(validation, fmt.Sprintf("%sMaxLength=%d", validationMarkerPrefix, *marker.MaxLength))
+	}
+
+	if marker.Pattern != nil {
+		validation = append(validation, fmt.Sprintf("%sPattern=`%s`", validationMarkerPrefix, *marker.Pattern))
+	}
+
+	if marker.Enum != nil {
+		if !marker.Type.IsInteger() && !marker.Type.IsFloat() && marker.Type != FieldString {
+			return nil, fmt.Errorf("%w; enum is unsupported for field type %s", ErrFieldMarkerInvalidValidation, marker.Type)
+		}
+
+		validation = append(validation, fmt.Sprintf("%sEnum=%s", validationMarkerPrefix, *marker.Enum))
+	}
+
+	if marker.Immutable != nil && *marker.Immutable {
+		validation = append(validation, fmt.Sprintf("%sXValidation:rule=%q,message=%q",
+			validationMarkerPrefix, immutableRule, immutableMessage))
+	}
+
+	if marker.Rule != nil {
+		if *marker.Rule == "" {
+			return nil, fmt.Errorf("%w; rule must not be empty", ErrFieldMarkerInvalidValidation)
+		}
+
+		validation = append(validation, fmt.Sprintf("%sXValidation:rule=%q", validationMarkerPrefix, *marker.Rule))
+	}
+
+	return validation, nil
+}
+
+// validateNumericValidation ensures that the minimum and maximum arguments of a field
+// marker are numbers, that they are requested for a numeric field and that they do
+// not conflict with one another.
+func validateNumericValidation(marker *FieldMarker) error {
+	if marker.Minimum == nil && marker.Maximum == nil {
+		return nil
+	}
+
+	if !marker.Type.IsInteger() && !marker.Type.IsFloat() {
+		return fmt.Errorf("%w; minimum and maximum are unsupported for field type %s",
+			ErrFieldMarkerInvalidValidation, marker.Type)
+	}
+
+	minimum, hasMinimum, err := validationNumber("minimum", marker.Minimum)
+	if err != nil {
+		return err
+	}
+
+	maximum, hasMaximum, err := validationNumber("maximum", marker.Maximum)
+	if err != nil {
+		return err
+	}
+
+	if hasMinimum && hasMaximum && minimum > maximum {
+		return fmt.Errorf("%w; minimum %v is greater than maximum %v",
+			ErrFieldMarkerInvalidValidation, marker.Minimum, marker.Maximum)
+	}
+
+	return nil
+}
+
+// validateStringValidation ensures that the minLength, maxLength and pattern arguments of
+// a field marker are requested for a string field and that they do not conflict with one
+// another.
+func validateStringValidation(marker *FieldMarker) error {
+	if marker.MinLength == nil && marker.MaxLength == nil && marker.Pattern == nil {
+		return nil
+	}
+
+	if marker.Type != FieldString {
+		return fmt.Errorf("%w; minLength, maxLength and pattern are unsupported for field type %s",
+			ErrFieldMarkerInvalidValidation, marker.Type)
+	}
+
+	if marker.MinLength != nil && marker.MaxLength != nil && *marker.MinLength > *marker.MaxLength {
+		return fmt.Errorf("%w; minLength %d is greater than maxLength %d",
+			ErrFieldMarkerInvalidValidation, *marker.MinLength, *marker.MaxLength)
+	}
+
+	return nil
+}
+
+// validationNumber converts the value of a numeric validation argument into a float64 so
+// that it may be compared.  It returns whether the argument was set.
+func validationNumber(argName string, value interface{}) (float64, bool, error) {
+	switch t := value.(type) {
+	case nil:
+		return 0, false, nil
+	case int:
+		return float64(t), true, nil
+	case float64:
+		return t, true, nil
+	default:
+		return 0, false, fmt.Errorf("%w; %s must be a number, got %v", ErrFieldMarkerInvalidValidation, argName, value)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getValidationMarkers(t *testing.T) {
+	t.Parallel()
+
+	testLength := 1
+	testShortLength := 0
+	testPattern := "^[a-z]+$"
+	testEnum := "aws;azure;vmware"
+	testImmutable := true
+	testRule := `self.startsWith("web")`
+	testEmptyRule := ""
+
+	tests := []struct {
+		name    string
+		marker  *FieldMarker
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "field marker without validation returns no markers",
+			marker: &FieldMarker{
+				Type: FieldString,
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "numeric field marker returns minimum and maximum markers",
+			marker: &FieldMarker{
+				Type:    FieldInt32,
+				Minimum: 1,
+				Maximum: 10.5,
+			},
+			want: []string{
+				"+kubebuilder:validation:Minimum=1",
+				"+kubebuilder:validation:Maximum=10.5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "string field marker returns string validation markers",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				MinLength: &testShortLength,
+				MaxLength: &testLength,
+				Pattern:   &testPattern,
+				Enum:      &testEnum,
+			},
+			want: []string{
+				"+kubebuilder:validation:MinLength=0",
+				"+kubebuilder:validation:MaxLength=1",
+				"+kubebuilder:validation:Pattern=`^[a-z]+$`",
+				"+kubebuilder:validation:Enum=aws;azure;vmware",
+			},
+			wantErr: false,
+		},
+		{
+			name: "immutable field marker returns a transition rule marker",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Immutable: &testImmutable,
+			},
+			want: []string{
+				`+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "field marker with rule returns a rule marker",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				Immutable: &testImmutable,
+				Rule:      &testRule,
+			},
+			want: []string{
+				`+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"`,
+				`+kubebuilder:validation:XValidation:rule="self.startsWith(\"web\")"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "field marker with empty rule returns an error",
+			marker: &FieldMarker{
+				Type: FieldString,
+				Rule: &testEmptyRule,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "minimum on a string field marker returns an error",
+			marker: &FieldMarker{
+				Type:    FieldString,
+				Minimum: 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "non-numeric minimum returns an error",
+			marker: &FieldMarker{
+				Type:    FieldInt,
+				Minimum: "one",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "minimum greater than maximum returns an error",
+			marker: &FieldMarker{
+				Type:    FieldInt,
+				Minimum: 10,
+				Maximum: 1,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "pattern on an int field marker returns an error",
+			marker: &FieldMarker{
+				Type:    FieldInt,
+				Pattern: &testPattern,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "minLength greater than maxLength returns an error",
+			marker: &FieldMarker{
+				Type:      FieldString,
+				MinLength: &testLength,
+				MaxLength: &testShortLength,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "enum on a bool field marker returns an error",
+			marker: &FieldMarker{
+				Type: FieldBool,
+				Enum: &testEnum,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := getValidationMarkers(tt.marker)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getValidationMarkers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
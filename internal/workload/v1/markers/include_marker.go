@@ -0,0 +1,327 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrIncludeMarkerAssociation       = errors.New("unable to associate include marker with 'field' or 'collectionField' marker")
+	ErrIncludeMarkerMissingFieldValue = errors.New("include marker missing 'collectionField', 'field' or 'value'")
+	ErrIncludeMarkerInvalidPlacement  = errors.New("include marker must be placed on a mapping key or a sequence item")
+	ErrIncludeMarkerInvalidType       = errors.New("expected include marker type")
+	ErrIncludeMarkerManifestCount     = errors.New("expected a single manifest when processing include markers")
+)
+
+const IncludeMarkerPrefix = "+operator-builder:include"
+
+// If we have a valid include marker, we will remove the related key or sequence item from
+// the object unless the associated field has the requested value.  These are the resultant
+// code snippets based on that logic.
+const (
+	includeKeyCode = `
+	// exclude %s unless it is included by its include markers
+	if %s {
+		delete(%s, %q)
+	}
+`
+
+	includeItemCode = `
+	// exclude %s unless it is included by its include markers
+	if %s {
+		items := %s
+		%s = append(items[:%d], items[%d:]...)
+	}
+`
+)
+
+// IncludeMarker is an object which represents a marker for a single key, and its value, or a
+// single sequence item within a resource.  It allows the key or item to be included only when
+// a field has a particular value.  An IncludeMarker is discovered when a manifest is parsed
+// and matches the constants defined by the includeMarker constant above.
+type IncludeMarker struct {
+	// inputs from the marker itself
+	Field           *string
+	CollectionField *string
+	Value           interface{}
+
+	// other fields which we use to pass information
+	node      *yaml.Node
+	condition string
+}
+
+// includeTarget represents a key or sequence item, within a manifest, which is controlled by
+// one or more include markers.
+type includeTarget struct {
+	// path is the human-readable path of the key or sequence item
+	path string
+
+	// parent is the source code expression of the mapping or sequence which holds the key or
+	// sequence item, while assignee is the expression to which a sequence is assigned
+	parent   string
+	assignee string
+
+	key      string
+	index    int
+	sequence bool
+	markers  []*IncludeMarker
+}
+
+//nolint:gocritic //needed to implement string interface
+func (im IncludeMarker) String() string {
+	return fmt.Sprintf("IncludeMarker{Field: %s CollectionField: %s Value: %v}",
+		im.GetField(),
+		im.GetCollectionField(),
+		im.Value,
+	)
+}
+
+// defineIncludeMarker will define an IncludeMarker and add it a registry of markers.
+func defineIncludeMarker(registry *marker.Registry) error {
+	includeMarker, err := marker.Define(IncludeMarkerPrefix, IncludeMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(includeMarker)
+
+	return nil
+}
+
+// GetName is a convenience function to return the name of the associated field marker.
+func (im *IncludeMarker) GetName() string {
+	if im.GetField() != "" {
+		return im.GetField()
+	}
+
+	return im.GetCollectionField()
+}
+
+// GetCollectionField is a convenience function to return the collection field as a string.
+func (im *IncludeMarker) GetCollectionField() string {
+	if im.CollectionField == nil {
+		return ""
+	}
+
+	return *im.CollectionField
+}
+
+// GetField is a convenience function to return the field as a string.
+func (im *IncludeMarker) GetField() string {
+	if im.Field == nil {
+		return ""
+	}
+
+	return *im.Field
+}
+
+// GetSpecPrefix is a convenience function to return the spec prefix of a requested
+// variable for an include marker.
+func (im *IncludeMarker) GetSpecPrefix() string {
+	if im.Field != nil {
+		return FieldSpecPrefix
+	}
+
+	return CollectionFieldSpecPrefix
+}
+
+// GetCondition returns the source code condition under which the key or sequence item that
+// the include marker was placed upon is excluded.
+func (im *IncludeMarker) GetCondition() string {
+	return im.condition
+}
+
+// Process will process an include marker from a collection of collection field markers
+// and field markers, associate them together and set the appropriate fields.
+func (im *IncludeMarker) Process(markers *MarkerCollection) error {
+	// ensure that both a field and value exist
+	if im.GetName() == "" || im.Value == nil {
+		return fmt.Errorf("%w for marker %s", ErrIncludeMarkerMissingFieldValue, im)
+	}
+
+	// include markers are associated with field markers and compared against their values
+	// in the same manner as resource markers
+	include := true
+
+	resourceMarker := &ResourceMarker{
+		Field:           im.Field,
+		CollectionField: im.CollectionField,
+		Value:           im.Value,
+		Include:         &include,
+	}
+
+	fieldMarker := resourceMarker.getFieldMarker(markers)
+	if fieldMarker == nil {
+		return fmt.Errorf("%w; %s", ErrIncludeMarkerAssociation, im)
+	}
+
+	resourceMarker.fieldMarker = fieldMarker
+
+	_, unmet, err := resourceMarker.getConditions()
+	if err != nil {
+		return fmt.Errorf("%w; error setting source code value for include marker: %v", err, im)
+	}
+
+	im.condition = unmet
+
+	return nil
+}
+
+// transformInclude will transform a YAML result for an include marker.  The manifest is
+// left as is, while the key or sequence item which the marker was placed upon is stored so
+// that it may be found later.
+func transformInclude(marker *IncludeMarker, result *inspect.YAMLResult) {
+	marker.node = result.Nodes[0]
+}
+
+// GetIncludeFieldCode inspects a single manifest for include markers and returns the source
+// code which removes each key or sequence item, from the object named by varName, unless it
+// is included by its include markers.  An empty string is returned if the manifest contains no
+// include markers.
+func GetIncludeFieldCode(manifest []byte, varName string, markers *MarkerCollection) (string, error) {
+	nodes, results, err := InspectForYAML(manifest, IncludeMarkerType)
+	if err != nil {
+		return "", fmt.Errorf("%w; error inspecting include markers", err)
+	}
+
+	if len(results) == 0 {
+		return "", nil
+	}
+
+	if len(nodes) != 1 || len(nodes[0].Content) == 0 {
+		return "", ErrIncludeMarkerManifestCount
+	}
+
+	nodeMarkers := map[*yaml.Node][]*IncludeMarker{}
+
+	for _, result := range results {
+		includeMarker, ok := result.Object.(*IncludeMarker)
+		if !ok {
+			return "", ErrIncludeMarkerInvalidType
+		}
+
+		if err := includeMarker.Process(markers); err != nil {
+			return "", err
+		}
+
+		nodeMarkers[includeMarker.node] = append(nodeMarkers[includeMarker.node], includeMarker)
+	}
+
+	targets := findIncludeTargets(nodes[0].Content[0], nodeMarkers, "", varName+".Object", varName+".Object")
+
+	var found int
+
+	for _, target := range targets {
+		// markers placed at the top of a manifest are found on its first key, which is
+		// typically the apiVersion or kind that every resource requires
+		if target.path == "apiVersion" || target.path == "kind" {
+			return "", fmt.Errorf("%w; use a resource marker to include an entire resource", ErrIncludeMarkerInvalidPlacement)
+		}
+
+		found += len(target.markers)
+	}
+
+	if found != len(results) {
+		return "", fmt.Errorf("%w; use a resource marker to include an entire resource", ErrIncludeMarkerInvalidPlacement)
+	}
+
+	// the targets are removed in the reverse order in which they are found so that nested
+	// targets are removed before their parents and sequence items are removed before the
+	// indices of the items which precede them are shifted
+	var code strings.Builder
+
+	for i := len(targets) - 1; i >= 0; i-- {
+		code.WriteString(targets[i].sourceCode())
+	}
+
+	return code.String(), nil
+}
+
+// findIncludeTargets recursively finds the keys and sequence items, within a node, which are
+// controlled by include markers.  The targets are returned in the order in which they appear
+// within the manifest.
+func findIncludeTargets(
+	node *yaml.Node,
+	nodeMarkers map[*yaml.Node][]*IncludeMarker,
+	path, assignee, expression string,
+) []*includeTarget {
+	var targets []*includeTarget
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			keyPath := strings.TrimPrefix(path+"."+key.Value, ".")
+
+			if includeMarkers, ok := nodeMarkers[key]; ok {
+				targets = append(targets, &includeTarget{
+					path:    keyPath,
+					parent:  expression,
+					key:     key.Value,
+					markers: includeMarkers,
+				})
+			}
+
+			keyAssignee := fmt.Sprintf("%s[%q]", expression, key.Value)
+
+			targets = append(
+				targets,
+				findIncludeTargets(value, nodeMarkers, keyPath, keyAssignee, keyAssignee+typeAssertion(value))...,
+			)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			if includeMarkers, ok := nodeMarkers[item]; ok {
+				targets = append(targets, &includeTarget{
+					path:     itemPath,
+					parent:   expression,
+					assignee: assignee,
+					index:    i,
+					sequence: true,
+					markers:  includeMarkers,
+				})
+			}
+
+			itemAssignee := fmt.Sprintf("%s[%d]", expression, i)
+
+			targets = append(
+				targets,
+				findIncludeTargets(item, nodeMarkers, itemPath, itemAssignee, itemAssignee+typeAssertion(item))...,
+			)
+		}
+	}
+
+	return targets
+}
+
+// sourceCode returns the source code which removes the target unless it is included by each
+// of its include markers.
+func (target *includeTarget) sourceCode() string {
+	conditions := make([]string, len(target.markers))
+
+	for i := range target.markers {
+		conditions[i] = target.markers[i].GetCondition()
+	}
+
+	condition := strings.Join(conditions, " || ")
+
+	if target.sequence {
+		return fmt.Sprintf(includeItemCode,
+			target.path, condition, target.parent, target.assignee, target.index, target.index+1,
+		)
+	}
+
+	return fmt.Sprintf(includeKeyCode, target.path, condition, target.parent, target.key)
+}
@@ -0,0 +1,262 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeMarker_String(t *testing.T) {
+	t.Parallel()
+
+	testField := "test"
+
+	tests := []struct {
+		name   string
+		marker IncludeMarker
+		want   string
+	}{
+		{
+			name: "include marker with a field",
+			marker: IncludeMarker{
+				Field: &testField,
+				Value: true,
+			},
+			want: "IncludeMarker{Field: test CollectionField:  Value: true}",
+		},
+		{
+			name: "include marker with a collection field",
+			marker: IncludeMarker{
+				CollectionField: &testField,
+				Value:           "test",
+			},
+			want: "IncludeMarker{Field:  CollectionField: test Value: test}",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.String())
+		})
+	}
+}
+
+func TestIncludeMarker_Process(t *testing.T) {
+	t.Parallel()
+
+	testField := "enableMetrics"
+	testCollectionField := "provider"
+	testUnknownField := "unknown"
+	testSensitive := true
+
+	markers := &MarkerCollection{
+		FieldMarkers: []*FieldMarker{
+			{
+				Name: "enableMetrics",
+				Type: FieldBool,
+			},
+			{
+				Name:      "password",
+				Type:      FieldString,
+				Sensitive: &testSensitive,
+			},
+		},
+		CollectionFieldMarkers: []*CollectionFieldMarker{
+			{
+				Name: "provider",
+				Type: FieldString,
+			},
+		},
+	}
+
+	testSensitiveField := "password"
+
+	tests := []struct {
+		name    string
+		marker  *IncludeMarker
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "include marker with a field",
+			marker: &IncludeMarker{
+				Field: &testField,
+				Value: true,
+			},
+			want: "parent.Spec.EnableMetrics != true",
+		},
+		{
+			name: "include marker with a collection field",
+			marker: &IncludeMarker{
+				CollectionField: &testCollectionField,
+				Value:           "aws",
+			},
+			want: `collection.Spec.Provider != "aws"`,
+		},
+		{
+			name: "include marker without a value",
+			marker: &IncludeMarker{
+				Field: &testField,
+			},
+			wantErr: true,
+		},
+		{
+			name: "include marker without a field",
+			marker: &IncludeMarker{
+				Value: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "include marker with an unknown field",
+			marker: &IncludeMarker{
+				Field: &testUnknownField,
+				Value: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "include marker with a mismatched type",
+			marker: &IncludeMarker{
+				Field: &testField,
+				Value: "true",
+			},
+			wantErr: true,
+		},
+		{
+			name: "include marker with a sensitive field",
+			marker: &IncludeMarker{
+				Field: &testSensitiveField,
+				Value: "secret",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.marker.Process(markers); (err != nil) != tt.wantErr {
+				t.Errorf("IncludeMarker.Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, tt.marker.GetCondition())
+		})
+	}
+}
+
+func TestGetIncludeFieldCode(t *testing.T) {
+	t.Parallel()
+
+	markers := &MarkerCollection{
+		FieldMarkers: []*FieldMarker{
+			{
+				Name: "enableMetrics",
+				Type: FieldBool,
+			},
+			{
+				Name: "environment",
+				Type: FieldString,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "manifest without include markers",
+			manifest: `
+kind: Deployment
+spec:
+  replicas: 1
+`,
+			want: "",
+		},
+		{
+			name: "manifest with an included key",
+			manifest: `
+kind: Deployment
+metadata:
+  annotations:
+    # +operator-builder:include:field=enableMetrics,value=true
+    prometheus.io/port: "9113"
+`,
+			want: `
+	// exclude metadata.annotations.prometheus.io/port unless it is included by its include markers
+	if parent.Spec.EnableMetrics != true {
+		delete(resourceObj.Object["metadata"].(map[string]interface{})["annotations"].(map[string]interface{}), "prometheus.io/port")
+	}
+`,
+		},
+		{
+			name: "manifest with included sequence items and a nested key",
+			manifest: `
+kind: Deployment
+spec:
+  containers:
+    # +operator-builder:include:field=enableMetrics,value=true
+    # +operator-builder:include:field=environment,value="prod"
+    - name: metrics
+    - name: webstore
+      # +operator-builder:include:field=enableMetrics,value=true
+      ports:
+        - containerPort: 9113
+`,
+			want: `
+	// exclude spec.containers[1].ports unless it is included by its include markers
+	if parent.Spec.EnableMetrics != true {
+		delete(resourceObj.Object["spec"].(map[string]interface{})["containers"].([]interface{})[1].(map[string]interface{}), "ports")
+	}
+
+	// exclude spec.containers[0] unless it is included by its include markers
+	if parent.Spec.EnableMetrics != true || parent.Spec.Environment != "prod" {
+		items := resourceObj.Object["spec"].(map[string]interface{})["containers"].([]interface{})
+		resourceObj.Object["spec"].(map[string]interface{})["containers"] = append(items[:0], items[1:]...)
+	}
+`,
+		},
+		{
+			name: "manifest with an include marker on the resource",
+			manifest: `
+# +operator-builder:include:field=enableMetrics,value=true
+kind: Deployment
+spec:
+  replicas: 1
+`,
+			wantErr: true,
+		},
+		{
+			name: "manifest with an include marker for an unknown field",
+			manifest: `
+kind: Deployment
+spec:
+  # +operator-builder:include:field=unknown,value=true
+  replicas: 1
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetIncludeFieldCode([]byte(tt.manifest), "resourceObj", markers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetIncludeFieldCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -21,9 +21,30 @@ const (
 	FieldMarkerType MarkerType = iota
 	CollectionMarkerType
 	ResourceMarkerType
+	TemplateMarkerType
+	StatusMarkerType
+	IncludeMarkerType
+	RepeatMarkerType
+	ReadyMarkerType
+	CustomMarkerType
 	UnknownMarkerType
 )
 
+// mergeCode is the source code used to merge the keys of a map field into the static
+// keys of a mapping found within a manifest.
+const mergeCode = `func() map[string]interface{} {
+	values := map[string]interface{}{
+%s	}
+
+	for key, value := range %s {
+		if _, found := values[key]; !found {
+			values[key] = value
+		}
+	}
+
+	return values
+}()`
+
 // FieldMarkerProcessor is an interface that requires specific methods that are
 // necessary for parsing a field marker or a collection field marker.
 type FieldMarkerProcessor interface {
@@ -31,14 +52,20 @@ type FieldMarkerProcessor interface {
 	GetDefault() interface{}
 	GetDescription() string
 	GetFieldType() FieldType
+	GetMerge() bool
 	GetOriginalValue() interface{}
 	GetReplaceText() string
 	GetSpecPrefix() string
 	GetSourceCodeVariable() string
+	GetTarget() FieldTarget
+	GetValidationMarkers() []string
+	GetPrintColumn() *PrintColumn
 
 	IsCollectionFieldMarker() bool
 	IsFieldMarker() bool
 	IsForCollection() bool
+	IsOptional() bool
+	IsSensitive() bool
 
 	SetDescription(string)
 	SetOriginalValue(string)
@@ -72,16 +99,25 @@ func ContainsMarkerType(s []MarkerType, e MarkerType) bool {
 
 // InspectForYAML will inspect yamlContent for a set of markers.  It will find
 // all of the markers within the yamlContent and return the resultant lines and
-// any associated errors.
+// any associated errors.  When both field markers and collection field markers are
+// inspected, the yamlContent belongs to a collection, which is itself the parent of
+// the child resources, so its collection fields are treated as fields of the parent.
 func InspectForYAML(yamlContent []byte, markerTypes ...MarkerType) ([]*yaml.Node, []*inspect.YAMLResult, error) {
 	insp, err := initializeMarkerInspector(markerTypes...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w; error initializing markers %v", err, markerTypes)
 	}
 
-	nodes, results, err := insp.InspectYAML(yamlContent, transformYAML)
+	transform := transformYAML
+	if ContainsMarkerType(markerTypes, FieldMarkerType) && ContainsMarkerType(markerTypes, CollectionMarkerType) {
+		transform = transformCollectionYAML
+	}
+
+	// the nodes and results are returned along with the errors of the markers, so that the markers
+	// which were found without errors may still be processed
+	nodes, results, err := insp.InspectYAML(yamlContent, transform)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w; error inspecting YAML for markers %v", err, markerTypes)
+		return nodes, results, fmt.Errorf("%w; error inspecting YAML for markers %v", err, markerTypes)
 	}
 
 	return nodes, results, nil
@@ -102,6 +138,18 @@ func initializeMarkerInspector(markerTypes ...MarkerType) (*inspect.Inspector, e
 			err = defineCollectionFieldMarker(registry)
 		case ResourceMarkerType:
 			err = defineResourceMarker(registry)
+		case TemplateMarkerType:
+			err = defineTemplateMarker(registry)
+		case StatusMarkerType:
+			err = defineStatusMarker(registry)
+		case IncludeMarkerType:
+			err = defineIncludeMarker(registry)
+		case RepeatMarkerType:
+			err = defineRepeatMarker(registry)
+		case ReadyMarkerType:
+			err = defineReadyMarker(registry)
+		case CustomMarkerType:
+			err = defineCustomMarkers(registry)
 		}
 	}
 
@@ -112,40 +160,133 @@ func initializeMarkerInspector(markerTypes ...MarkerType) (*inspect.Inspector, e
 	return inspect.NewInspector(registry), nil
 }
 
+// setKubebuilderMarkers sets the validation markers and the print column which are requested
+// by the arguments of a field marker.
+func setKubebuilderMarkers(marker *FieldMarker) error {
+	if err := validateSensitive(marker); err != nil {
+		return err
+	}
+
+	validationMarkers, err := getValidationMarkers(marker)
+	if err != nil {
+		return err
+	}
+
+	printColumn, err := getPrintColumn(marker)
+	if err != nil {
+		return err
+	}
+
+	marker.validationMarkers = validationMarkers
+	marker.printColumn = printColumn
+
+	return nil
+}
+
 // transformYAML will transform a YAML result into the proper format for scaffolding
-// resultant code and API definitions.
+// resultant code and API definitions.  Errors are returned along with the position of the
+// marker which caused them, and the errors of all of the results are returned at once.
 func transformYAML(results ...*inspect.YAMLResult) error {
+	var errs inspect.PositionErrors
+
 	for _, result := range results {
-		// convert to interface
-		var marker FieldMarkerProcessor
+		if err := transformYAMLResult(result); err != nil {
+			errs = append(errs, inspect.NewPositionError(err, result))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
 
+// transformCollectionYAML will transform the YAML results of the manifests of a collection.  The
+// collection is the parent of its own child resources, so a collection field marker is simply a
+// field marker to itself and the collection references of a template marker refer to the parent.
+func transformCollectionYAML(results ...*inspect.YAMLResult) error {
+	for _, result := range results {
 		switch t := result.Object.(type) {
-		case FieldMarker:
-			t.sourceCodeVar = getSourceCodeVariable(&t)
-			marker = &t
 		case CollectionFieldMarker:
-			t.sourceCodeVar = getSourceCodeVariable(&t)
-			marker = &t
-		default:
-			continue
+			result.Object = FieldMarker(t)
+		case TemplateMarker:
+			t.collectionIsParent = true
+			result.Object = t
 		}
+	}
 
-		// get common variables and confirm that we are not working with a reserved marker
-		if isReserved(marker.GetName()) {
-			return fmt.Errorf("%s %w", marker.GetName(), ErrFieldMarkerReserved)
-		}
+	return transformYAML(results...)
+}
 
-		key, value := getKeyValue(result)
+// transformYAMLResult will transform a single YAML result.
+func transformYAMLResult(result *inspect.YAMLResult) error {
+	// convert to interface
+	var marker FieldMarkerProcessor
 
-		setComments(marker, result, key, value)
+	var err error
 
-		if err := setValue(marker, value); err != nil {
+	switch t := result.Object.(type) {
+	case FieldMarker:
+		t.sourceCodeVar = getFieldSourceCodeVariable(&t)
+		err = setKubebuilderMarkers(&t)
+		marker = &t
+	case CollectionFieldMarker:
+		t.sourceCodeVar = getFieldSourceCodeVariable(&t)
+		err = setKubebuilderMarkers((*FieldMarker)(&t))
+		marker = &t
+	case TemplateMarker:
+		if err := transformTemplate(&t, result); err != nil {
 			return fmt.Errorf("%w; error setting value for marker %s", err, result.MarkerText)
 		}
 
-		result.Object = marker
+		result.Object = &t
+
+		return nil
+	case StatusMarker:
+		if err := transformStatus(&t, result); err != nil {
+			return fmt.Errorf("%w; error processing marker %s", err, result.MarkerText)
+		}
+
+		result.Object = &t
+
+		return nil
+	case IncludeMarker:
+		transformInclude(&t, result)
+
+		result.Object = &t
+
+		return nil
+	default:
+		return transformCustom(result)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w; error setting kubebuilder markers for marker %s", err, result.MarkerText)
+	}
+
+	// get common variables and confirm that we are not working with a reserved marker
+	if isReserved(marker.GetName()) {
+		return fmt.Errorf("%s %w", marker.GetName(), ErrFieldMarkerReserved)
+	}
+
+	key, value := getKeyValue(result)
+
+	if marker.IsOptional() && key == value {
+		return fmt.Errorf(
+			"%w; optional is only supported for the value of a mapping key for marker %s",
+			ErrFieldMarkerInvalidOptional, result.MarkerText,
+		)
+	}
+
+	setComments(marker, result, key, value)
+
+	if marker.GetTarget() == FieldTargetKey {
+		if err := setKey(marker, key, value); err != nil {
+			return fmt.Errorf("%w; error setting key for marker %s", err, result.MarkerText)
+		}
+	} else if err := setValue(marker, value); err != nil {
+		return fmt.Errorf("%w; error setting value for marker %s", err, result.MarkerText)
 	}
 
+	result.Object = marker
+
 	return nil
 }
 
@@ -175,8 +316,9 @@ func isReserved(fieldName string) bool {
 // getSourceCodeFieldVariable gets a full variable name for a marker as it is intended to be
 // passed into the generate package to generate the source code.  This includes particular
 // tags that are needed by the generator to properly identify when a variable starts and ends.
+// Because the variable is substituted into a string value, non-string types are converted.
 func getSourceCodeFieldVariable(marker FieldMarkerProcessor) string {
-	return fmt.Sprintf("!!start %s !!end", marker.GetSourceCodeVariable())
+	return fmt.Sprintf("!!start %s !!end", marker.GetFieldType().StringConversion(marker.GetSourceCodeVariable()))
 }
 
 // getSourceCodeVariable gets a full variable name for a marker as it is intended to be
@@ -185,6 +327,27 @@ func getSourceCodeVariable(marker MarkerProcessor) string {
 	return fmt.Sprintf("%s.%s", marker.GetSpecPrefix(), strings.Title((marker.GetName())))
 }
 
+// getFieldSourceCodeVariable gets the variable name for a field marker or a collection field
+// marker.  The value of a sensitive field is never stored within the custom resource, so its
+// placeholder is substituted instead and later resolved from a secret by the controller.
+func getFieldSourceCodeVariable(marker FieldMarkerProcessor) string {
+	if marker.IsSensitive() {
+		return NewSensitiveField(marker).GetVariableName()
+	}
+
+	return getSourceCodeVariable(marker)
+}
+
+// getValueSourceCode gets the source code which is substituted as the value of a field.  Optional
+// fields which are represented as pointers are dereferenced, as they are only set when non-nil.
+func getValueSourceCode(marker FieldMarkerProcessor) string {
+	if marker.IsOptional() && !marker.GetFieldType().IsNillable() {
+		return "*" + marker.GetSourceCodeVariable()
+	}
+
+	return marker.GetSourceCodeVariable()
+}
+
 // getKeyValue gets the key and value from a YAML result.
 func getKeyValue(result *inspect.YAMLResult) (key, value *yaml.Node) {
 	if len(result.Nodes) > 1 {
@@ -202,10 +365,6 @@ func setComments(marker FieldMarkerProcessor, result *inspect.YAMLResult, key, v
 		key.HeadComment = key.HeadComment + "\n# " + marker.GetDescription()
 	}
 
-	// set replace text to ensure that our markers are commented
-	replaceText := strings.TrimSuffix(result.MarkerText, "\n")
-	replaceText = strings.ReplaceAll(replaceText, "\n", "\n#")
-
 	// set the append text to notify the user where a marker was originated from in their source code
 	var appendText string
 	switch t := marker.(type) {
@@ -215,12 +374,53 @@ func setComments(marker FieldMarkerProcessor, result *inspect.YAMLResult, key, v
 		appendText = "controlled by collection field: " + t.Name
 	}
 
+	replaceMarkerComments(result, key, value, appendText)
+}
+
+// replaceMarkerComments replaces the text of a marker within the comments of the yaml nodes
+// with the append text, which notifies the user where a value originated from.
+func replaceMarkerComments(result *inspect.YAMLResult, key, value *yaml.Node, appendText string) {
+	// set replace text to ensure that our markers are commented
+	replaceText := strings.TrimSuffix(result.MarkerText, "\n")
+	replaceText = strings.ReplaceAll(replaceText, "\n", "\n#")
+
 	// set the comments on the yaml nodes
 	key.FootComment = ""
 	key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, appendText)
 	value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, appendText)
 }
 
+// transformTemplate will transform a YAML result for a template marker.  The value is
+// substituted with the source code which builds the value from the referenced fields.
+func transformTemplate(marker *TemplateMarker, result *inspect.YAMLResult) error {
+	if err := marker.setSourceCode(); err != nil {
+		return err
+	}
+
+	key, value := getKeyValue(result)
+
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%w; %s requires a scalar value", ErrTemplateMarkerInvalid, marker)
+	}
+
+	replaceMarkerComments(result, key, value, "controlled by template: "+marker.Value)
+
+	const varTag = "!!var"
+
+	sourceCode := marker.GetSourceCode()
+
+	// a loop variable which replaces a value that is not a string is substituted as is, so
+	// that the type of the value is retained (e.g. the port of a repeated service)
+	if variable := marker.getLoopVariable(); variable != "" && value.ShortTag() != "!!str" {
+		sourceCode = variable
+	}
+
+	value.Tag = varTag
+	value.Value = sourceCode
+
+	return nil
+}
+
 // setValue will set the value appropriately.  This is based on whether the marker has
 // requested replacement text.
 func setValue(marker FieldMarkerProcessor, value *yaml.Node) error {
@@ -228,6 +428,28 @@ func setValue(marker FieldMarkerProcessor, value *yaml.Node) error {
 
 	const strTag = "!!str"
 
+	if marker.GetMerge() && !marker.GetFieldType().IsMap() {
+		return fmt.Errorf("%w; merge is unsupported for field type %s", ErrFieldMarkerInvalidType, marker.GetFieldType())
+	}
+
+	tag := varTag
+
+	if marker.IsOptional() {
+		if err := validateOptional(marker); err != nil {
+			return err
+		}
+
+		tag = optionalTag
+	}
+
+	if marker.IsSensitive() && value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("%w; sensitive requires a scalar value", ErrFieldMarkerInvalidSensitive)
+	}
+
+	if fieldType := marker.GetFieldType(); fieldType.IsArray() || fieldType.IsMap() || fieldType.IsKubernetesType() {
+		return setCompositeValue(marker, value, tag)
+	}
+
 	markerReplaceText := marker.GetReplaceText()
 
 	marker.SetOriginalValue(value.Value)
@@ -242,9 +464,88 @@ func setValue(marker FieldMarkerProcessor, value *yaml.Node) error {
 
 		value.Value = re.ReplaceAllString(value.Value, getSourceCodeFieldVariable(marker))
 	} else {
-		value.Tag = varTag
-		value.Value = marker.GetSourceCodeVariable()
+		value.Tag = tag
+		value.Value = getValueSourceCode(marker)
 	}
 
 	return nil
 }
+
+// setCompositeValue will set the value for a marker with an array, map or Kubernetes field
+// type.  The entire node is substituted with the variable, while the original value is
+// stored in flow style so that it may be used as a sample value.
+func setCompositeValue(marker FieldMarkerProcessor, value *yaml.Node, tag string) error {
+	fieldType := marker.GetFieldType()
+
+	switch {
+	case fieldType.IsArray() && value.Kind != yaml.SequenceNode:
+		return fmt.Errorf("%w; field type %s requires a sequence value", ErrFieldMarkerInvalidType, fieldType)
+	case fieldType.IsMap() && value.Kind != yaml.MappingNode:
+		return fmt.Errorf("%w; field type %s requires a mapping value", ErrFieldMarkerInvalidType, fieldType)
+	}
+
+	if marker.GetReplaceText() != "" {
+		return fmt.Errorf("%w; replace is unsupported for field type %s", ErrFieldMarkerInvalidType, fieldType)
+	}
+
+	if marker.GetDefault() != nil {
+		return fmt.Errorf("%w; default is unsupported for field type %s", ErrFieldMarkerInvalidType, fieldType)
+	}
+
+	originalValue, err := yaml.Marshal(flowStyleNode(value))
+	if err != nil {
+		return fmt.Errorf("%w; unable to marshal %s value for marker %s", err, fieldType, marker.GetName())
+	}
+
+	marker.SetOriginalValue(strings.TrimSuffix(string(originalValue), "\n"))
+
+	sourceCode := fieldType.UnstructuredConversion(getValueSourceCode(marker))
+
+	if marker.GetMerge() {
+		if sourceCode, err = getMergeSourceCode(marker, value); err != nil {
+			return err
+		}
+	}
+
+	value.Kind = yaml.ScalarNode
+	value.Style = 0
+	value.Tag = tag
+	value.Value = sourceCode
+	value.Content = nil
+
+	return nil
+}
+
+// getMergeSourceCode returns the source code which merges the map requested by the user
+// into the static keys of a mapping.  Static keys from the manifest always take precedence
+// so that values such as selector labels may not be overwritten.
+func getMergeSourceCode(marker FieldMarkerProcessor, value *yaml.Node) (string, error) {
+	var staticValues strings.Builder
+
+	for i := 0; i < len(value.Content); i += 2 {
+		key, val := value.Content[i], value.Content[i+1]
+
+		if val.Kind != yaml.ScalarNode {
+			return "", fmt.Errorf("%w; unable to merge non-scalar value for key %s", ErrFieldMarkerInvalidType, key.Value)
+		}
+
+		staticValues.WriteString(fmt.Sprintf("\t\t%q: %q,\n", key.Value, val.Value))
+	}
+
+	return fmt.Sprintf(mergeCode, staticValues.String(), marker.GetSourceCodeVariable()), nil
+}
+
+// flowStyleNode returns a copy of a node, and its content, in flow style with all comments
+// removed so that it may be represented on a single line.
+func flowStyleNode(node *yaml.Node) *yaml.Node {
+	flowNode := *node
+	flowNode.Style |= yaml.FlowStyle
+	flowNode.HeadComment, flowNode.LineComment, flowNode.FootComment = "", "", ""
+	flowNode.Content = make([]*yaml.Node, len(node.Content))
+
+	for i := range node.Content {
+		flowNode.Content[i] = flowStyleNode(node.Content[i])
+	}
+
+	return &flowNode
+}
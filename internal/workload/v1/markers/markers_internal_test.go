@@ -403,6 +403,8 @@ func Test_setValue(t *testing.T) {
 	//nolint: goconst
 	testInvalidReplaceText := "*&^%"
 	testReplaceText := "<replace me>"
+	testMerge := true
+	testOptional := true
 
 	type args struct {
 		marker FieldMarkerProcessor
@@ -467,6 +469,270 @@ func Test_setValue(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure sequence value is replaced when array type is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldType("[]string"),
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.SequenceNode,
+					Tag:  "!!seq",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "first"},
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "second"},
+					},
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!var",
+				Value: fmt.Sprintf(arrayConversionCode, "parent.Spec.Test.Field", "value"),
+			},
+		},
+		{
+			name: "ensure array type with scalar value returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldType("[]string"),
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!str",
+					Value: "first",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure array type with replace text returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldType("[]string"),
+					Replace:       &testReplaceText,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.SequenceNode,
+					Tag:  "!!seq",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure mapping value is replaced when map type is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldStringMap,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.MappingNode,
+					Tag:  "!!map",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "app"},
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "test"},
+					},
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!var",
+				Value: fmt.Sprintf(mapConversionCode, "parent.Spec.Test.Field"),
+			},
+		},
+		{
+			name: "ensure mapping value is merged when map type with merge is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldStringMap,
+					Merge:         &testMerge,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.MappingNode,
+					Tag:  "!!map",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "app"},
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "test"},
+					},
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind: yaml.ScalarNode,
+				Tag:  "!!var",
+				Value: `func() map[string]interface{} {
+	values := map[string]interface{}{
+		"app": "test",
+	}
+
+	for key, value := range parent.Spec.Test.Field {
+		if _, found := values[key]; !found {
+			values[key] = value
+		}
+	}
+
+	return values
+}()`,
+			},
+		},
+		{
+			name: "ensure subtree is replaced when kubernetes type is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldType("corev1.ResourceRequirements"),
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.MappingNode,
+					Tag:  "!!map",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "limits"},
+						{
+							Kind: yaml.MappingNode,
+							Tag:  "!!map",
+							Content: []*yaml.Node{
+								{Kind: yaml.ScalarNode, Tag: "!!str", Value: "cpu"},
+								{Kind: yaml.ScalarNode, Tag: "!!str", Value: "100m"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!var",
+				Value: "parent.Spec.Test.Field",
+			},
+		},
+		{
+			name: "ensure map type with sequence value returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldStringMap,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.SequenceNode,
+					Tag:  "!!seq",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure merge with non-map type returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldString,
+					Merge:         &testMerge,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!str",
+					Value: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure merge with nested mapping value returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldStringMap,
+					Merge:         &testMerge,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.MappingNode,
+					Tag:  "!!map",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "app"},
+						{Kind: yaml.MappingNode, Tag: "!!map"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure optional value is dereferenced when pointer type is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldInt32,
+					Optional:      &testOptional,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!int",
+					Value: "2",
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!optional",
+				Value: "*parent.Spec.Test.Field",
+			},
+		},
+		{
+			name: "ensure optional value is not dereferenced when nillable type is requested",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldType("[]string"),
+					Optional:      &testOptional,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind: yaml.SequenceNode,
+					Tag:  "!!seq",
+					Content: []*yaml.Node{
+						{Kind: yaml.ScalarNode, Tag: "!!str", Value: "a"},
+					},
+				},
+			},
+			wantErr: false,
+			want: &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!optional",
+				Value: fmt.Sprintf(arrayConversionCode, "parent.Spec.Test.Field", "value"),
+			},
+		},
+		{
+			name: "ensure optional with default returns an error",
+			args: args{
+				marker: &FieldMarker{
+					Name:          "test.field",
+					Type:          FieldInt32,
+					Default:       2,
+					Optional:      &testOptional,
+					sourceCodeVar: "parent.Spec.Test.Field",
+				},
+				value: &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!int",
+					Value: "2",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -720,6 +986,51 @@ func Test_transformYAML(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure valid template marker does not return error",
+			args: args{
+				results: []*inspect.YAMLResult{
+					{
+						Result: &parser.Result{
+							MarkerText: "test",
+							Object: TemplateMarker{
+								Value: "{{ .environment }}-{{ .appName }}",
+							},
+						},
+						Nodes: []*yaml.Node{
+							{
+								Kind:  yaml.ScalarNode,
+								Tag:   "!!str",
+								Value: "dev-app",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure template marker on a non-scalar value returns an error",
+			args: args{
+				results: []*inspect.YAMLResult{
+					{
+						Result: &parser.Result{
+							MarkerText: "test",
+							Object: TemplateMarker{
+								Value: "{{ .environment }}-{{ .appName }}",
+							},
+						},
+						Nodes: []*yaml.Node{
+							{
+								Kind: yaml.MappingNode,
+								Tag:  "!!map",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -732,3 +1043,200 @@ func Test_transformYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestInspectForYAML_Position(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     inspect.Position
+	}{
+		{
+			name: "ensure an invalid argument is found at its position within a line comment",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+data:
+  replicas: 2  # +operator-builder:field:name=replicas,type=integr
+`,
+			want: inspect.Position{Line: 4, Column: 61},
+		},
+		{
+			name: "ensure an invalid marker is found at its position within a head comment",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+data:
+  # +docs: the number of replicas
+
+  # +operator-builder:field:name=replicas,type=int,minimum=abc
+  replicas: 2
+`,
+			want: inspect.Position{Line: 6, Column: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := InspectForYAML([]byte(tt.manifest), FieldMarkerType)
+
+			var positionErr *inspect.PositionError
+			if assert.ErrorAs(t, err, &positionErr) {
+				assert.Equal(t, tt.want, positionErr.Position)
+			}
+		})
+	}
+}
+
+func TestInspectForYAML_Errors(t *testing.T) {
+	t.Parallel()
+
+	manifest := `apiVersion: v1
+kind: ConfigMap
+data:
+  replicas: 2  # +operator-builder:field:name=replicas,type=integr
+  # +operator-builder:field:name=size,type=int,minimum=abc
+  size: 1
+  name: test  # +operator-builder:field:name=name,type=string
+`
+
+	_, _, err := InspectForYAML([]byte(manifest), FieldMarkerType)
+
+	var positionErrs inspect.PositionErrors
+	if !assert.ErrorAs(t, err, &positionErrs) {
+		return
+	}
+
+	positions := []inspect.Position{}
+
+	for _, positionErr := range positionErrs {
+		var found *inspect.PositionError
+		if assert.ErrorAs(t, positionErr, &found) {
+			positions = append(positions, found.Position)
+		}
+	}
+
+	assert.ElementsMatch(t, []inspect.Position{{Line: 4, Column: 61}, {Line: 5, Column: 5}}, positions)
+}
+
+func TestInspectForYAML_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		marker      string
+		markerTypes []MarkerType
+		wantErr     error
+		wantHint    string
+	}{
+		{
+			name:        "ensure a near-miss marker name produces error with a suggestion",
+			marker:      "+operator-builder:feild:name=replicas,type=int",
+			markerTypes: []MarkerType{FieldMarkerType},
+			wantErr:     parser.ErrUnknownMarker,
+			wantHint:    `did you mean "+operator-builder:field"?`,
+		},
+		{
+			name:        "ensure a near-miss argument name produces error with a suggestion",
+			marker:      "+operator-builder:field:name=replicas,type=int,defualt=2",
+			markerTypes: []MarkerType{FieldMarkerType},
+			wantErr:     parser.ErrUnknownArgument,
+			wantHint:    `did you mean "default"?`,
+		},
+		{
+			name:        "ensure an unknown argument name produces error without a suggestion",
+			marker:      "+operator-builder:ready:preset=deployment,timeout=30",
+			markerTypes: []MarkerType{ReadyMarkerType},
+			wantErr:     parser.ErrUnknownArgument,
+		},
+		{
+			name:        "ensure a marker of another type is not mistaken for a near-miss",
+			marker:      "+operator-builder:repeat:field=items",
+			markerTypes: []MarkerType{ReadyMarkerType},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifest := fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\ndata:\n  replicas: 2  # %s\n", tt.marker)
+
+			_, _, err := InspectForYAML([]byte(manifest), tt.markerTypes...)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantHint != "" {
+				assert.Contains(t, err.Error(), tt.wantHint)
+			} else {
+				assert.NotContains(t, err.Error(), "did you mean")
+			}
+		})
+	}
+}
+
+func TestInspectForYAML_collectionIsParent(t *testing.T) {
+	t.Parallel()
+
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  replicas: "replicas=2"  # +operator-builder:collection:field:name=replicas,type=int,replace="2"
+  host: test.acme.com  # +operator-builder:template:value="{{ .collection.domain }}.acme.com"
+`
+
+	tests := []struct {
+		name        string
+		markerTypes []MarkerType
+		want        []string
+		notWant     []string
+	}{
+		{
+			name:        "ensure collection fields are fields of the parent within the manifests of a collection",
+			markerTypes: []MarkerType{FieldMarkerType, CollectionMarkerType, TemplateMarkerType},
+			want:        []string{"strconv.Itoa(parent.Spec.Replicas)", "parent.Spec.Domain)"},
+			notWant:     []string{"collection.Spec"},
+		},
+		{
+			name:        "ensure collection fields are fields of the collection within the manifests of a component",
+			markerTypes: []MarkerType{CollectionMarkerType, TemplateMarkerType},
+			want:        []string{"strconv.Itoa(collection.Spec.Replicas)", "collection.Spec.Domain)"},
+			notWant:     []string{"parent.Spec"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nodes, _, err := InspectForYAML([]byte(manifest), tt.markerTypes...)
+			if !assert.NoError(t, err) || !assert.Len(t, nodes, 1) {
+				return
+			}
+
+			got, err := yaml.Marshal(nodes[0])
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, want := range tt.want {
+				assert.Contains(t, string(got), want)
+			}
+
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, string(got), notWant)
+			}
+		})
+	}
+}
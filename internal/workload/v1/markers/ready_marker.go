@@ -0,0 +1,210 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrReadyMarkerInvalidPreset = errors.New("ready marker preset is invalid")
+	ErrReadyMarkerInvalidCheck  = errors.New("ready marker must request exactly one of 'preset', 'condition' or 'path'")
+	ErrReadyMarkerInvalidPath   = errors.New("ready marker path is invalid")
+	ErrReadyMarkerInvalidValue  = errors.New("ready marker value is invalid")
+	ErrReadyMarkerInvalidType   = errors.New("expected ready marker type")
+)
+
+const ReadyMarkerPrefix = "+operator-builder:ready"
+
+// ReadyMarkerPreset defines a well-known set of readiness checks for a kind of resource.
+type ReadyMarkerPreset string
+
+const (
+	ReadyMarkerPresetDeployment               ReadyMarkerPreset = "deployment"
+	ReadyMarkerPresetStatefulSet              ReadyMarkerPreset = "statefulset"
+	ReadyMarkerPresetDaemonSet                ReadyMarkerPreset = "daemonset"
+	ReadyMarkerPresetJob                      ReadyMarkerPreset = "job"
+	ReadyMarkerPresetPersistentVolumeClaim    ReadyMarkerPreset = "persistentvolumeclaim"
+	ReadyMarkerPresetPod                      ReadyMarkerPreset = "pod"
+	ReadyMarkerPresetCustomResourceDefinition ReadyMarkerPreset = "customresourcedefinition"
+)
+
+// readyPresetChecks are the source code snippets of the readiness checks which are generated for
+// each preset.  Each snippet calls a function which is generated within the resources file.
+func readyPresetChecks() map[ReadyMarkerPreset][]string {
+	return map[ReadyMarkerPreset][]string{
+		ReadyMarkerPresetDeployment:               {`readyCondition("Available")`, `readyReplicas()`},
+		ReadyMarkerPresetStatefulSet:              {`readyReplicas()`},
+		ReadyMarkerPresetDaemonSet:                {`readyScheduled()`},
+		ReadyMarkerPresetJob:                      {`readyCondition("Complete")`},
+		ReadyMarkerPresetPersistentVolumeClaim:    {`readyField("Bound", "status", "phase")`},
+		ReadyMarkerPresetPod:                      {`readyCondition("Ready")`},
+		ReadyMarkerPresetCustomResourceDefinition: {`readyCondition("Established")`},
+	}
+}
+
+// ReadyMarker is an object which represents a marker that checks the readiness of a live child
+// resource.  A child resource is ready when all of the checks requested by its ready markers
+// pass.  A ReadyMarker is discovered when a manifest is parsed and matches the constants defined
+// by the readyMarker constant above.
+type ReadyMarker struct {
+	// inputs from the marker itself
+	Preset    ReadyMarkerPreset `marker:",optional"`
+	Condition *string
+	Path      *string
+	Value     interface{} `marker:",optional"`
+
+	// other values which we use to pass information
+	sourcePath []string
+}
+
+//nolint:gocritic //needed to implement string interface
+func (rm ReadyMarker) String() string {
+	return fmt.Sprintf("ReadyMarker{Preset: %s Condition: %q Path: %q Value: %v}",
+		rm.Preset,
+		rm.GetCondition(),
+		rm.GetPath(),
+		rm.Value,
+	)
+}
+
+// defineReadyMarker will define a ReadyMarker and add it a registry of markers.
+func defineReadyMarker(registry *marker.Registry) error {
+	readyMarker, err := marker.Define(ReadyMarkerPrefix, ReadyMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(readyMarker)
+
+	return nil
+}
+
+// UnmarshalMarkerArg will convert the preset argument within a ready marker into its underlying
+// ReadyMarkerPreset object.
+func (p *ReadyMarkerPreset) UnmarshalMarkerArg(in string) error {
+	preset := ReadyMarkerPreset(strings.ToLower(in))
+
+	if _, ok := readyPresetChecks()[preset]; !ok {
+		return fmt.Errorf("%w; %s must be one of [%s, %s, %s, %s, %s, %s, %s]",
+			ErrReadyMarkerInvalidPreset, in,
+			ReadyMarkerPresetDeployment, ReadyMarkerPresetStatefulSet, ReadyMarkerPresetDaemonSet,
+			ReadyMarkerPresetJob, ReadyMarkerPresetPersistentVolumeClaim, ReadyMarkerPresetPod,
+			ReadyMarkerPresetCustomResourceDefinition,
+		)
+	}
+
+	*p = preset
+
+	return nil
+}
+
+// GetCondition returns the type of the condition requested by the marker itself.
+func (rm *ReadyMarker) GetCondition() string {
+	if rm.Condition == nil {
+		return ""
+	}
+
+	return *rm.Condition
+}
+
+// GetPath returns the path requested by the marker itself.
+func (rm *ReadyMarker) GetPath() string {
+	if rm.Path == nil {
+		return ""
+	}
+
+	return *rm.Path
+}
+
+// GetSourcePath returns the path of the field, within the live child resource, which is checked
+// by the marker.
+func (rm *ReadyMarker) GetSourcePath() []string {
+	return rm.sourcePath
+}
+
+// Process validates a ready marker and sets the path of the field which is checked, if any.  The
+// path may be requested either as a dot-separated path (e.g. status.phase) or as a simple
+// JSONPath expression (e.g. {.status.phase}).
+func (rm *ReadyMarker) Process() error {
+	requested := 0
+
+	for _, isSet := range []bool{rm.Preset != "", rm.Condition != nil, rm.Path != nil} {
+		if isSet {
+			requested++
+		}
+	}
+
+	if requested != 1 {
+		return fmt.Errorf("%w for marker %s", ErrReadyMarkerInvalidCheck, rm)
+	}
+
+	if rm.Condition != nil && strings.TrimSpace(rm.GetCondition()) == "" {
+		return fmt.Errorf("%w for marker %s", ErrReadyMarkerInvalidCheck, rm)
+	}
+
+	if rm.Path == nil {
+		if rm.Value != nil {
+			return fmt.Errorf("%w; value may only be requested along with a path for marker %s",
+				ErrReadyMarkerInvalidValue, rm,
+			)
+		}
+
+		return nil
+	}
+
+	switch rm.Value.(type) {
+	case nil, string, bool, int, float64:
+	default:
+		return fmt.Errorf("%w; unsupported type %T for marker %s", ErrReadyMarkerInvalidValue, rm.Value, rm)
+	}
+
+	path := strings.TrimSpace(rm.GetPath())
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	if !fieldPathRegex.MatchString(path) {
+		return fmt.Errorf("%w; %q is not a dot-separated path for marker %s", ErrReadyMarkerInvalidPath, rm.GetPath(), rm)
+	}
+
+	rm.sourcePath = strings.Split(path, ".")
+
+	return nil
+}
+
+// GetCheckCode returns the source code snippets of the readiness checks which are requested by
+// the marker.
+func (rm *ReadyMarker) GetCheckCode() []string {
+	switch {
+	case rm.Preset != "":
+		return readyPresetChecks()[rm.Preset]
+	case rm.Condition != nil:
+		return []string{fmt.Sprintf("readyCondition(%q)", rm.GetCondition())}
+	default:
+		quoted := make([]string, len(rm.sourcePath))
+
+		for i := range rm.sourcePath {
+			quoted[i] = fmt.Sprintf("%q", rm.sourcePath[i])
+		}
+
+		return []string{fmt.Sprintf("readyField(%s, %s)", rm.getValueCode(), strings.Join(quoted, ", "))}
+	}
+}
+
+// getValueCode returns the value which the field of a path check must have as it is represented
+// in the source code.  A nil value only requires that the field is set.
+func (rm *ReadyMarker) getValueCode() string {
+	switch value := rm.Value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
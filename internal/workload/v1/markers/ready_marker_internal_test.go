@@ -0,0 +1,178 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyMarkerPreset_UnmarshalMarkerArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    ReadyMarkerPreset
+		wantErr bool
+	}{
+		{
+			name: "ensure a known preset is unmarshaled",
+			arg:  "deployment",
+			want: ReadyMarkerPresetDeployment,
+		},
+		{
+			name: "ensure a preset is unmarshaled regardless of case",
+			arg:  "PersistentVolumeClaim",
+			want: ReadyMarkerPresetPersistentVolumeClaim,
+		},
+		{
+			name:    "ensure an unknown preset produces error",
+			arg:     "service",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var preset ReadyMarkerPreset
+
+			err := preset.UnmarshalMarkerArg(tt.arg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrReadyMarkerInvalidPreset)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, preset)
+		})
+	}
+}
+
+func TestReadyMarker_Process(t *testing.T) {
+	t.Parallel()
+
+	testString := func(value string) *string { return &value }
+
+	tests := []struct {
+		name    string
+		marker  *ReadyMarker
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "ensure a preset is valid",
+			marker: &ReadyMarker{Preset: ReadyMarkerPresetJob},
+		},
+		{
+			name:   "ensure a condition is valid",
+			marker: &ReadyMarker{Condition: testString("Ready")},
+		},
+		{
+			name:   "ensure a dot-separated path is valid",
+			marker: &ReadyMarker{Path: testString("status.phase"), Value: "Bound"},
+			want:   []string{"status", "phase"},
+		},
+		{
+			name:   "ensure a jsonpath expression is valid",
+			marker: &ReadyMarker{Path: testString("{.status.loadBalancer.ingress}")},
+			want:   []string{"status", "loadBalancer", "ingress"},
+		},
+		{
+			name:    "ensure a marker without a check produces error",
+			marker:  &ReadyMarker{},
+			wantErr: ErrReadyMarkerInvalidCheck,
+		},
+		{
+			name:    "ensure a marker with multiple checks produces error",
+			marker:  &ReadyMarker{Preset: ReadyMarkerPresetPod, Condition: testString("Ready")},
+			wantErr: ErrReadyMarkerInvalidCheck,
+		},
+		{
+			name:    "ensure an empty condition produces error",
+			marker:  &ReadyMarker{Condition: testString(" ")},
+			wantErr: ErrReadyMarkerInvalidCheck,
+		},
+		{
+			name:    "ensure a value without a path produces error",
+			marker:  &ReadyMarker{Condition: testString("Ready"), Value: "True"},
+			wantErr: ErrReadyMarkerInvalidValue,
+		},
+		{
+			name:    "ensure an invalid path produces error",
+			marker:  &ReadyMarker{Path: testString("{.status.conditions[0]}")},
+			wantErr: ErrReadyMarkerInvalidPath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.marker.Process()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.marker.GetSourcePath())
+		})
+	}
+}
+
+func TestReadyMarker_GetCheckCode(t *testing.T) {
+	t.Parallel()
+
+	testString := func(value string) *string { return &value }
+
+	tests := []struct {
+		name   string
+		marker *ReadyMarker
+		want   []string
+	}{
+		{
+			name:   "ensure a preset returns all of its checks",
+			marker: &ReadyMarker{Preset: ReadyMarkerPresetDeployment},
+			want:   []string{`readyCondition("Available")`, `readyReplicas()`},
+		},
+		{
+			name:   "ensure a condition returns a condition check",
+			marker: &ReadyMarker{Condition: testString("Synced")},
+			want:   []string{`readyCondition("Synced")`},
+		},
+		{
+			name:   "ensure a path without a value only checks that the field is set",
+			marker: &ReadyMarker{Path: testString("status.phase")},
+			want:   []string{`readyField(nil, "status", "phase")`},
+		},
+		{
+			name:   "ensure a path with a string value is quoted",
+			marker: &ReadyMarker{Path: testString("status.phase"), Value: "Bound"},
+			want:   []string{`readyField("Bound", "status", "phase")`},
+		},
+		{
+			name:   "ensure a path with a non-string value is not quoted",
+			marker: &ReadyMarker{Path: testString("status.ready"), Value: true},
+			want:   []string{`readyField(true, "status", "ready")`},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, tt.marker.Process())
+			assert.Equal(t, tt.want, tt.marker.GetCheckCode())
+		})
+	}
+}
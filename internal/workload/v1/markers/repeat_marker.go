@@ -0,0 +1,206 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrRepeatMarkerAssociation     = errors.New("unable to associate repeat marker with 'field' or 'collectionField' marker")
+	ErrRepeatMarkerMissingField    = errors.New("repeat marker missing 'collectionField' or 'field'")
+	ErrRepeatMarkerUnsupportedType = errors.New("repeat marker requires an integer field or an array field of a builtin type")
+	ErrRepeatMarkerSensitive       = errors.New("repeat marker may not be associated with a sensitive field")
+	ErrRepeatMarkerInvalidType     = errors.New("expected repeat marker type")
+)
+
+const (
+	RepeatMarkerPrefix = "+operator-builder:repeat"
+
+	// RepeatIndexVariable and RepeatItemVariable are the loop variables which hold the index
+	// and the item of the current copy of a repeated resource.
+	RepeatIndexVariable = "repeatIndex"
+	RepeatItemVariable  = "repeatItem"
+)
+
+// If we have a valid repeat marker, a copy of the related object is created for each item of
+// the associated field.  These are the resultant code snippets based on that logic.  The
+// closing brace of the loop is scaffolded after the copy is appended to the resource objects.
+const (
+	repeatRangeCode = `
+	// create a copy of the resource for each item of %s
+	for %srange %s {`
+
+	repeatCountCode = `
+	// create a copy of the resource for each count of %s
+	for repeatIndex := 0; repeatIndex < int(%s); repeatIndex++ {`
+
+	repeatCountItemCode = `
+		repeatItem := repeatIndex
+`
+)
+
+// repeatVariableRegex matches the loop variables of a repeated resource within source code.
+var repeatVariableRegex = regexp.MustCompile(`\b(` + RepeatIndexVariable + `|` + RepeatItemVariable + `)\b`)
+
+// RepeatMarker is an object which represents a marker for an entire resource.  It creates
+// a copy of the resource for each item of an array field, or for each count of an integer
+// field.  A RepeatMarker is discovered when a manifest is parsed and matches the constants
+// defined by the repeatMarker constant above.
+type RepeatMarker struct {
+	// inputs from the marker itself
+	Field           *string
+	CollectionField *string
+
+	// other fields which we use to pass information
+	fieldMarker FieldMarkerProcessor
+}
+
+//nolint:gocritic //needed to implement string interface
+func (rm RepeatMarker) String() string {
+	return fmt.Sprintf("RepeatMarker{Field: %s CollectionField: %s}",
+		rm.GetField(),
+		rm.GetCollectionField(),
+	)
+}
+
+// defineRepeatMarker will define a RepeatMarker and add it a registry of markers.
+func defineRepeatMarker(registry *marker.Registry) error {
+	repeatMarker, err := marker.Define(RepeatMarkerPrefix, RepeatMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(repeatMarker)
+
+	return nil
+}
+
+// GetName is a convenience function to return the name of the associated field marker.
+func (rm *RepeatMarker) GetName() string {
+	if rm.GetField() != "" {
+		return rm.GetField()
+	}
+
+	return rm.GetCollectionField()
+}
+
+// GetCollectionField is a convenience function to return the collection field as a string.
+func (rm *RepeatMarker) GetCollectionField() string {
+	if rm.CollectionField == nil {
+		return ""
+	}
+
+	return *rm.CollectionField
+}
+
+// GetField is a convenience function to return the field as a string.
+func (rm *RepeatMarker) GetField() string {
+	if rm.Field == nil {
+		return ""
+	}
+
+	return *rm.Field
+}
+
+// GetSpecPrefix is a convenience function to return the spec prefix of a requested
+// variable for a repeat marker.
+func (rm *RepeatMarker) GetSpecPrefix() string {
+	if rm.Field != nil {
+		return FieldSpecPrefix
+	}
+
+	return CollectionFieldSpecPrefix
+}
+
+// Process will process a repeat marker from a collection of collection field markers
+// and field markers, associate them together and ensure that the associated field may
+// be repeated over.
+func (rm *RepeatMarker) Process(markers *MarkerCollection) error {
+	if rm.GetName() == "" {
+		return fmt.Errorf("%w for marker %s", ErrRepeatMarkerMissingField, rm)
+	}
+
+	// repeat markers are associated with field markers in the same manner as resource markers
+	resourceMarker := &ResourceMarker{
+		Field:           rm.Field,
+		CollectionField: rm.CollectionField,
+	}
+
+	fieldMarker := resourceMarker.getFieldMarker(markers)
+	if fieldMarker == nil {
+		return fmt.Errorf("%w; %s", ErrRepeatMarkerAssociation, rm)
+	}
+
+	// the value of a sensitive field is not known until the controller resolves it
+	if fieldMarker.IsSensitive() {
+		return fmt.Errorf("%w; %s", ErrRepeatMarkerSensitive, rm)
+	}
+
+	fieldType := fieldMarker.GetFieldType()
+
+	// optional integer fields are represented as pointers which may not be counted
+	repeatable := (fieldType.IsArray() && !fieldType.IsKubernetesType()) ||
+		(fieldType.IsInteger() && !fieldMarker.IsOptional())
+
+	if !repeatable {
+		return fmt.Errorf("%w; field %s of type %s for marker %s",
+			ErrRepeatMarkerUnsupportedType, fieldMarker.GetName(), fieldType, rm,
+		)
+	}
+
+	rm.fieldMarker = fieldMarker
+
+	return nil
+}
+
+// GetLoopCode returns the source code which opens the loop that creates a copy of the
+// resource for each item of the associated field.  The loop variables are only declared
+// when they are referenced by the source code of the resource, as unused variables do
+// not compile.
+func (rm *RepeatMarker) GetLoopCode(sourceCode string) string {
+	variable := getSourceCodeVariable(rm)
+	usesIndex, usesItem := ReferencesRepeatVariables(sourceCode)
+
+	if rm.fieldMarker.GetFieldType().IsInteger() {
+		if usesItem {
+			return fmt.Sprintf(repeatCountCode, variable, variable) + repeatCountItemCode
+		}
+
+		return fmt.Sprintf(repeatCountCode, variable, variable)
+	}
+
+	// range clauses omit the loop variables which are not referenced
+	var clause string
+
+	switch {
+	case usesItem && usesIndex:
+		clause = RepeatIndexVariable + ", " + RepeatItemVariable + " := "
+	case usesItem:
+		clause = "_, " + RepeatItemVariable + " := "
+	case usesIndex:
+		clause = RepeatIndexVariable + " := "
+	}
+
+	return fmt.Sprintf(repeatRangeCode, variable, clause, variable)
+}
+
+// ReferencesRepeatVariables returns whether source code references the index and the item
+// loop variables of a repeated resource.
+func ReferencesRepeatVariables(sourceCode string) (index, item bool) {
+	for _, match := range repeatVariableRegex.FindAllString(sourceCode, -1) {
+		switch match {
+		case RepeatIndexVariable:
+			index = true
+		case RepeatItemVariable:
+			item = true
+		}
+	}
+
+	return index, item
+}
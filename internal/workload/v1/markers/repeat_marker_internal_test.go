@@ -0,0 +1,266 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepeatMarker_String(t *testing.T) {
+	t.Parallel()
+
+	testField := "test"
+
+	tests := []struct {
+		name   string
+		marker RepeatMarker
+		want   string
+	}{
+		{
+			name:   "repeat marker with a field",
+			marker: RepeatMarker{Field: &testField},
+			want:   "RepeatMarker{Field: test CollectionField: }",
+		},
+		{
+			name:   "repeat marker with a collection field",
+			marker: RepeatMarker{CollectionField: &testField},
+			want:   "RepeatMarker{Field:  CollectionField: test}",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.String())
+		})
+	}
+}
+
+func TestRepeatMarker_Process(t *testing.T) {
+	t.Parallel()
+
+	testOptional := true
+	testSensitive := true
+
+	markers := &MarkerCollection{
+		FieldMarkers: []*FieldMarker{
+			{Name: "ports", Type: "[]int"},
+			{Name: "shards", Type: FieldInt32},
+			{Name: "optionalShards", Type: FieldInt, Optional: &testOptional},
+			{Name: "tolerations", Type: "[]corev1.Toleration"},
+			{Name: "provider", Type: FieldString},
+			{Name: "hosts", Type: "[]string", Sensitive: &testSensitive},
+		},
+		CollectionFieldMarkers: []*CollectionFieldMarker{
+			{Name: "regions", Type: "[]string"},
+		},
+	}
+
+	testField := func(name string) *string { return &name }
+
+	tests := []struct {
+		name    string
+		marker  *RepeatMarker
+		wantErr bool
+	}{
+		{
+			name:   "repeat marker with an array field",
+			marker: &RepeatMarker{Field: testField("ports")},
+		},
+		{
+			name:   "repeat marker with an integer field",
+			marker: &RepeatMarker{Field: testField("shards")},
+		},
+		{
+			name:   "repeat marker with an array collection field",
+			marker: &RepeatMarker{CollectionField: testField("regions")},
+		},
+		{
+			name:    "repeat marker without a field",
+			marker:  &RepeatMarker{},
+			wantErr: true,
+		},
+		{
+			name:    "repeat marker with an unknown field",
+			marker:  &RepeatMarker{Field: testField("unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "repeat marker with a string field",
+			marker:  &RepeatMarker{Field: testField("provider")},
+			wantErr: true,
+		},
+		{
+			name:    "repeat marker with an optional integer field",
+			marker:  &RepeatMarker{Field: testField("optionalShards")},
+			wantErr: true,
+		},
+		{
+			name:    "repeat marker with an array of a kubernetes type",
+			marker:  &RepeatMarker{Field: testField("tolerations")},
+			wantErr: true,
+		},
+		{
+			name:    "repeat marker with a sensitive field",
+			marker:  &RepeatMarker{Field: testField("hosts")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.marker.Process(markers); (err != nil) != tt.wantErr {
+				t.Errorf("RepeatMarker.Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepeatMarker_GetLoopCode(t *testing.T) {
+	t.Parallel()
+
+	testField := "ports"
+
+	tests := []struct {
+		name        string
+		fieldMarker FieldMarkerProcessor
+		sourceCode  string
+		want        string
+	}{
+		{
+			name:        "array field with both loop variables",
+			fieldMarker: &FieldMarker{Name: testField, Type: "[]int"},
+			sourceCode:  `"name": fmt.Sprintf("svc-%v", repeatIndex), "port": repeatItem,`,
+			want: `
+	// create a copy of the resource for each item of parent.Spec.Ports
+	for repeatIndex, repeatItem := range parent.Spec.Ports {`,
+		},
+		{
+			name:        "array field with the item loop variable",
+			fieldMarker: &FieldMarker{Name: testField, Type: "[]int"},
+			sourceCode:  `"port": repeatItem,`,
+			want: `
+	// create a copy of the resource for each item of parent.Spec.Ports
+	for _, repeatItem := range parent.Spec.Ports {`,
+		},
+		{
+			name:        "array field with the index loop variable",
+			fieldMarker: &FieldMarker{Name: testField, Type: "[]int"},
+			sourceCode:  `"name": fmt.Sprintf("svc-%v", repeatIndex),`,
+			want: `
+	// create a copy of the resource for each item of parent.Spec.Ports
+	for repeatIndex := range parent.Spec.Ports {`,
+		},
+		{
+			name:        "array field without loop variables",
+			fieldMarker: &FieldMarker{Name: testField, Type: "[]int"},
+			sourceCode:  `"name": "svc",`,
+			want: `
+	// create a copy of the resource for each item of parent.Spec.Ports
+	for range parent.Spec.Ports {`,
+		},
+		{
+			name:        "integer field with the item loop variable",
+			fieldMarker: &FieldMarker{Name: testField, Type: FieldInt64},
+			sourceCode:  `"shard": repeatItem,`,
+			want: `
+	// create a copy of the resource for each count of parent.Spec.Ports
+	for repeatIndex := 0; repeatIndex < int(parent.Spec.Ports); repeatIndex++ {
+		repeatItem := repeatIndex
+`,
+		},
+		{
+			name:        "integer field with the index loop variable",
+			fieldMarker: &FieldMarker{Name: testField, Type: FieldInt},
+			sourceCode:  `"shard": repeatIndex,`,
+			want: `
+	// create a copy of the resource for each count of parent.Spec.Ports
+	for repeatIndex := 0; repeatIndex < int(parent.Spec.Ports); repeatIndex++ {`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rm := &RepeatMarker{
+				Field:       &testField,
+				fieldMarker: tt.fieldMarker,
+			}
+
+			assert.Equal(t, tt.want, rm.GetLoopCode(tt.sourceCode))
+		})
+	}
+}
+
+func TestReferencesRepeatVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sourceCode string
+		wantIndex  bool
+		wantItem   bool
+	}{
+		{
+			name:       "source code with both loop variables",
+			sourceCode: `fmt.Sprintf("%v-%v", repeatIndex, repeatItem)`,
+			wantIndex:  true,
+			wantItem:   true,
+		},
+		{
+			name:       "source code with the item loop variable",
+			sourceCode: `"externalName": repeatItem,`,
+			wantItem:   true,
+		},
+		{
+			name:       "source code with a similarly named variable",
+			sourceCode: `"name": parent.Spec.RepeatIndexName, "other": repeatItems,`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			index, item := ReferencesRepeatVariables(tt.sourceCode)
+			assert.Equal(t, tt.wantIndex, index)
+			assert.Equal(t, tt.wantItem, item)
+		})
+	}
+}
+
+func TestInspectForYAML_repeatTemplate(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+kind: Service
+metadata:
+  name: webstore-0 # +operator-builder:template:value="webstore-{{ .repeat.index }}"
+spec:
+  externalName: webstore.acme.com # +operator-builder:template:value="{{ .repeat.item }}"
+  ports:
+    - port: 8080 # +operator-builder:template:value="{{ .repeat.item }}"
+`
+
+	nodes, results, err := InspectForYAML([]byte(manifest), TemplateMarkerType)
+	if err != nil {
+		t.Fatalf("InspectForYAML() error = %v", err)
+	}
+
+	assert.Len(t, results, 3)
+
+	metadata := nodes[0].Content[0].Content[3]
+	spec := nodes[0].Content[0].Content[5]
+	port := spec.Content[3].Content[0].Content[1]
+
+	assert.Equal(t, `fmt.Sprintf("webstore-%v", repeatIndex)`, metadata.Content[1].Value)
+	assert.Equal(t, `fmt.Sprintf("%v", repeatItem)`, spec.Content[1].Value)
+	assert.Equal(t, "repeatItem", port.Value)
+}
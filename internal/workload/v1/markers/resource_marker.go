@@ -6,19 +6,29 @@ package markers
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
 )
 
 var (
-	ErrResourceMarkerInvalid           = errors.New("resource marker is invalid")
-	ErrResourceMarkerCount             = errors.New("expected only 1 resource marker")
-	ErrResourceMarkerAssociation       = errors.New("unable to associate resource marker with 'field' or 'collectionField' marker")
-	ErrResourceMarkerTypeMismatch      = errors.New("resource marker and field marker have mismatched types")
-	ErrResourceMarkerInvalidType       = errors.New("expected resource marker type")
-	ErrResourceMarkerUnknownValueType  = errors.New("resource marker 'value' is of unknown type")
-	ErrResourceMarkerMissingFieldValue = errors.New("resource marker missing 'collectionField', 'field' or 'value'")
-	ErrResourceMarkerMissingInclude    = errors.New("resource marker missing 'include' value")
+	ErrResourceMarkerInvalid                = errors.New("resource marker is invalid")
+	ErrResourceMarkerInvalidOperator        = errors.New("resource marker operator is invalid")
+	ErrResourceMarkerOperatorMismatch       = errors.New("resource markers for the same resource have mismatched operators")
+	ErrResourceMarkerInvalidComparison      = errors.New("resource marker compare argument is invalid")
+	ErrResourceMarkerAssociation            = errors.New("unable to associate resource marker with 'field' or 'collectionField' marker")
+	ErrResourceMarkerTypeMismatch           = errors.New("resource marker and field marker have mismatched types")
+	ErrResourceMarkerInvalidType            = errors.New("expected resource marker type")
+	ErrResourceMarkerUnknownValueType       = errors.New("resource marker 'value' is of unknown type")
+	ErrResourceMarkerMissingFieldValue      = errors.New("resource marker missing 'collectionField', 'field' or 'value'")
+	ErrResourceMarkerMissingInclude         = errors.New("resource marker missing 'include' value")
+	ErrResourceMarkerSensitive              = errors.New("resource marker may not be associated with a sensitive field")
+	ErrResourceMarkerInvalidWave            = errors.New("resource marker wave must not be negative")
+	ErrResourceMarkerWaveMismatch           = errors.New("resource markers for the same resource have mismatched waves")
+	ErrResourceMarkerInvalidIgnoreField     = errors.New("resource marker ignoreFields argument is invalid")
+	ErrResourceMarkerPolicyConflict         = errors.New("resource markers may not request both createOnly and ignoreFields")
+	ErrResourceMarkerInvalidDeletionPolicy  = errors.New("resource marker deletionPolicy argument is invalid")
+	ErrResourceMarkerDeletionPolicyMismatch = errors.New("resource markers for the same resource have mismatched deletion policies")
 )
 
 const (
@@ -28,18 +38,13 @@ const (
 )
 
 // If we have a valid resource marker,  we will either include or exclude the
-// related object based on the inputs on the resource marker itself.  These are
-// the resultant code snippets based on that logic.
-const (
-	includeCode = `if %s != %s {
+// related object based on the inputs on the resource marker itself.  This is
+// the resultant code snippet based on that logic, given the condition under
+// which the related object is not deployed.
+const includeCode = `if %s {
 		return []client.Object{}, nil
 	}`
 
-	excludeCode = `if %s == %s {
-		return []client.Object{}, nil
-	}`
-)
-
 // ResourceMarker is an object which represents a marker for an entire resource.  It
 // allows actions against a resource.  A ResourceMarker is discovered when a manifest
 // is parsed and matches the constants defined by the collectionFieldMarker
@@ -48,12 +53,19 @@ type ResourceMarker struct {
 	// inputs from the marker itself
 	Field           *string
 	CollectionField *string
-	Value           interface{}
+	Value           interface{} `marker:",optional"`
 	Include         *bool
+	Operator        ResourceMarkerOperator   `marker:",optional"`
+	Compare         ResourceMarkerComparison `marker:",optional"`
+	Wave            *int
+	CreateOnly      *bool
+	IgnoreFields    *string
+	DeletionPolicy  ResourceMarkerDeletionPolicy `marker:",optional"`
 
 	// other field which we use to pass information
-	includeCode string
-	fieldMarker FieldMarkerProcessor
+	includeCode      string
+	excludeCondition string
+	fieldMarker      FieldMarkerProcessor
 }
 
 // String simply returns the marker as it should be printed in string format.
@@ -100,6 +112,16 @@ func (rm *ResourceMarker) GetIncludeCode() string {
 	return rm.includeCode
 }
 
+// GetComparison is a convenience function to return the comparison of the resource marker.  Values
+// are compared for equality unless another comparison is requested.
+func (rm *ResourceMarker) GetComparison() ResourceMarkerComparison {
+	if rm.Compare == "" {
+		return ResourceMarkerCompareEq
+	}
+
+	return rm.Compare
+}
+
 // GetName is a convenience function to return the name of the associated field marker.
 func (rm *ResourceMarker) GetName() string {
 	if rm.GetField() != "" {
@@ -145,6 +167,11 @@ func (rm *ResourceMarker) Process(markers *MarkerCollection) error {
 		return fmt.Errorf("%w; %s", err, ErrResourceMarkerInvalid)
 	}
 
+	// a marker which only requests a wave or a policy is not associated with a field
+	if rm.isUnconditional() {
+		return nil
+	}
+
 	// associate field markers from a collection of markers to this resource marker
 	if fieldMarker := rm.getFieldMarker(markers); fieldMarker != nil {
 		rm.fieldMarker = fieldMarker
@@ -163,6 +190,18 @@ func (rm *ResourceMarker) Process(markers *MarkerCollection) error {
 // validate checks for a valid resource marker and returns an error if the
 // resource marker is invalid.
 func (rm *ResourceMarker) validate() error {
+	if rm.Wave != nil && *rm.Wave < 0 {
+		return fmt.Errorf("%w for marker %s", ErrResourceMarkerInvalidWave, rm)
+	}
+
+	if err := rm.validatePolicy(); err != nil {
+		return err
+	}
+
+	if rm.isUnconditional() {
+		return nil
+	}
+
 	// check include field for a provided value
 	// NOTE: this field is mandatory now, but could be optional later, so we return
 	// an error here rather than using a pointer to a bool to control the mandate.
@@ -170,11 +209,18 @@ func (rm *ResourceMarker) validate() error {
 		return fmt.Errorf("%w for marker %s", ErrResourceMarkerMissingInclude, rm)
 	}
 
-	// ensure that both a field and value exist
-	if !rm.hasField() || !rm.hasValue() {
+	// ensure that both a field and value exist, unless only the existence of the
+	// field is checked
+	if !rm.hasField() || (!rm.hasValue() && rm.GetComparison() != ResourceMarkerCompareExists) {
 		return fmt.Errorf("%w for marker %s", ErrResourceMarkerMissingFieldValue, rm)
 	}
 
+	if rm.hasValue() && rm.GetComparison() == ResourceMarkerCompareExists {
+		return fmt.Errorf("%w; %s does not accept a value for marker %s",
+			ErrResourceMarkerInvalidComparison, ResourceMarkerCompareExists, rm,
+		)
+	}
+
 	return nil
 }
 
@@ -185,6 +231,21 @@ func (rm *ResourceMarker) hasField() bool {
 	return rm.GetName() != ""
 }
 
+// isUnconditional determines whether a resource marker only requests the wave in which its
+// resource is created, or the policy with which its resource is reconciled (e.g.
+// +operator-builder:resource:wave=1), rather than including the resource based upon the value
+// of a field.
+func (rm *ResourceMarker) isUnconditional() bool {
+	requestsOption := rm.Wave != nil || rm.CreateOnly != nil || rm.IgnoreFields != nil || rm.DeletionPolicy != ""
+
+	return requestsOption &&
+		!rm.hasField() &&
+		!rm.hasValue() &&
+		rm.Include == nil &&
+		rm.Operator == "" &&
+		rm.Compare == ""
+}
+
 // hasValue determines whether or not a parsed resource marker has a value
 // to check against.
 func (rm *ResourceMarker) hasValue() bool {
@@ -239,41 +300,109 @@ func (rm *ResourceMarker) getFieldMarker(markers *MarkerCollection) FieldMarkerP
 
 // setSourceCode sets the source code to use as generated by the resource marker.
 func (rm *ResourceMarker) setSourceCode() error {
-	var sourceCodeVar, sourceCodeValue string
+	// get the conditions under which the resource marker is met and unmet
+	met, unmet, err := rm.getConditions()
+	if err != nil {
+		return err
+	}
 
-	// get the source code variable
-	sourceCodeVar = getSourceCodeVariable(rm)
+	// set the include code for this marker
+	if *rm.Include {
+		rm.excludeCondition = unmet
+	} else {
+		rm.excludeCondition = met
+	}
+
+	rm.includeCode = fmt.Sprintf(includeCode, rm.excludeCondition)
 
+	return nil
+}
+
+// getSourceCodeValue returns the value of the resource marker as it is compared against the
+// associated field marker in the source code, ensuring that the types match.
+func (rm *ResourceMarker) getSourceCodeValue() (string, error) {
 	// set the source code value and ensure the types match
 	switch value := rm.Value.(type) {
-	case string, int, bool:
-		fieldMarkerType := rm.fieldMarker.GetFieldType().String()
-		resourceMarkerType := fmt.Sprintf("%T", value)
+	case string, int, float64, bool:
+		fieldMarkerType := rm.fieldMarker.GetFieldType()
 
-		if fieldMarkerType != resourceMarkerType {
-			return fmt.Errorf("%w; expected: %s, got: %s for marker %s",
+		if !isValueOfType(value, fieldMarkerType) {
+			return "", fmt.Errorf("%w; expected: %T, got: %s for marker %s",
 				ErrResourceMarkerTypeMismatch,
-				resourceMarkerType,
+				value,
 				fieldMarkerType,
 				rm,
 			)
 		}
 
-		if fieldMarkerType == "string" {
-			sourceCodeValue = fmt.Sprintf("%q", value)
-		} else {
-			sourceCodeValue = fmt.Sprintf("%v", value)
+		if fieldMarkerType == FieldString {
+			return fmt.Sprintf("%q", value), nil
 		}
+
+		return fmt.Sprintf("%v", value), nil
 	default:
-		return ErrResourceMarkerUnknownValueType
+		return "", ErrResourceMarkerUnknownValueType
 	}
+}
 
-	// set the include code for this marker
-	if *rm.Include {
-		rm.includeCode = fmt.Sprintf(includeCode, sourceCodeVar, sourceCodeValue)
-	} else {
-		rm.includeCode = fmt.Sprintf(excludeCode, sourceCodeVar, sourceCodeValue)
+// GetResourceIncludeCode returns the include code for a set of processed resource markers
+// which were found on the same resource.  The resource is only deployed when the conditions
+// of all of the markers are met, or when the condition of any of the markers is met, based
+// upon the operator requested by the markers.  Markers which do not request an operator defer
+// to the operator requested by the other markers, while markers which only request a wave or
+// a policy are ignored.
+func GetResourceIncludeCode(resourceMarkers []*ResourceMarker) (string, error) {
+	var operator ResourceMarkerOperator
+
+	conditions := []string{}
+
+	for _, rm := range resourceMarkers {
+		if rm.isUnconditional() {
+			continue
+		}
+
+		if rm.Operator != "" {
+			if operator != "" && operator != rm.Operator {
+				return "", fmt.Errorf("%w; %s and %s requested for marker %s",
+					ErrResourceMarkerOperatorMismatch, operator, rm.Operator, rm,
+				)
+			}
+
+			operator = rm.Operator
+		}
+
+		conditions = append(conditions, rm.excludeCondition)
 	}
 
-	return nil
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	// the conditions are those under which the resource is not deployed, so the resource
+	// is not deployed if any condition is met when all markers must be met, and vice versa
+	separator := " || "
+	if operator == ResourceMarkerOperatorOr {
+		separator = " && "
+	}
+
+	return fmt.Sprintf(includeCode, strings.Join(conditions, separator)), nil
+}
+
+// isValueOfType determines if a value parsed from a resource marker may be compared
+// against a field of a particular FieldType.  Integer values may be compared against
+// any numeric field type, while float values may only be compared against float
+// field types.
+func isValueOfType(value interface{}, fieldType FieldType) bool {
+	switch value.(type) {
+	case string:
+		return fieldType == FieldString
+	case bool:
+		return fieldType == FieldBool
+	case int:
+		return fieldType.IsInteger() || fieldType.IsFloat()
+	case float64:
+		return fieldType.IsFloat()
+	default:
+		return false
+	}
 }
@@ -359,9 +359,18 @@ func TestResourceMarker_validate(t *testing.T) {
 		CollectionField *string
 		Value           interface{}
 		Include         *bool
+		Compare         ResourceMarkerComparison
+		Wave            *int
+		CreateOnly      *bool
+		IgnoreFields    *string
 		fieldMarker     FieldMarkerProcessor
 	}
 
+	testWave := 1
+	testNegativeWave := -1
+	testCreateOnly := true
+	testIgnoreFields := "spec.replicas"
+
 	tests := []struct {
 		name    string
 		fields  fields
@@ -404,6 +413,85 @@ func TestResourceMarker_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "missing value with exists comparison does not produce error",
+			fields: fields{
+				Field:       &testField,
+				Include:     &testInclude,
+				Compare:     ResourceMarkerCompareExists,
+				fieldMarker: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "value with exists comparison produces error",
+			fields: fields{
+				Field:       &testField,
+				Value:       &testValue,
+				Include:     &testInclude,
+				Compare:     ResourceMarkerCompareExists,
+				fieldMarker: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "wave only does not produce error",
+			fields: fields{
+				Wave: &testWave,
+			},
+			wantErr: false,
+		},
+		{
+			name: "wave with a field and a value does not produce error",
+			fields: fields{
+				Field:   &testField,
+				Value:   &testValue,
+				Include: &testInclude,
+				Wave:    &testWave,
+			},
+			wantErr: false,
+		},
+		{
+			name: "wave with a field and without include produces error",
+			fields: fields{
+				Field: &testField,
+				Value: &testValue,
+				Wave:  &testWave,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative wave produces error",
+			fields: fields{
+				Wave: &testNegativeWave,
+			},
+			wantErr: true,
+		},
+		{
+			name: "create only policy does not produce error",
+			fields: fields{
+				CreateOnly: &testCreateOnly,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ignore fields policy with a field and a value does not produce error",
+			fields: fields{
+				Field:        &testField,
+				Value:        &testValue,
+				Include:      &testInclude,
+				IgnoreFields: &testIgnoreFields,
+			},
+			wantErr: false,
+		},
+		{
+			name: "create only and ignore fields policies produce error",
+			fields: fields{
+				CreateOnly:   &testCreateOnly,
+				IgnoreFields: &testIgnoreFields,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -415,6 +503,10 @@ func TestResourceMarker_validate(t *testing.T) {
 				CollectionField: tt.fields.CollectionField,
 				Value:           tt.fields.Value,
 				Include:         tt.fields.Include,
+				Compare:         tt.fields.Compare,
+				Wave:            tt.fields.Wave,
+				CreateOnly:      tt.fields.CreateOnly,
+				IgnoreFields:    tt.fields.IgnoreFields,
 				fieldMarker:     tt.fields.fieldMarker,
 			}
 			if err := rm.validate(); (err != nil) != tt.wantErr {
@@ -887,6 +979,22 @@ func TestResourceMarker_setSourceCode(t *testing.T) {
 		Type: FieldUnknownType,
 	}
 
+	testInt64Marker := &FieldMarker{
+		Name: testSourceCodeField,
+		Type: FieldInt64,
+	}
+
+	testFloatMarker := &FieldMarker{
+		Name: testSourceCodeField,
+		Type: FieldFloat64,
+	}
+
+	testSensitiveMarker := &FieldMarker{
+		Name:      testSourceCodeField,
+		Type:      FieldString,
+		Sensitive: &includeTrue,
+	}
+
 	type fields struct {
 		Field           *string
 		CollectionField *string
@@ -955,6 +1063,42 @@ func TestResourceMarker_setSourceCode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure valid int64 field marker produces no error on int value",
+			fields: fields{
+				fieldMarker: testInt64Marker,
+				Include:     &includeTrue,
+				Value:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure valid float field marker produces no error on float value",
+			fields: fields{
+				fieldMarker: testFloatMarker,
+				Include:     &includeTrue,
+				Value:       1.5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure valid float field marker produces no error on int value",
+			fields: fields{
+				fieldMarker: testFloatMarker,
+				Include:     &includeTrue,
+				Value:       1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid int field marker with float value produces error",
+			fields: fields{
+				fieldMarker: testInt64Marker,
+				Include:     &includeTrue,
+				Value:       1.5,
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure invalid marker with unknown resource marker value type produces error",
 			fields: fields{
@@ -964,6 +1108,15 @@ func TestResourceMarker_setSourceCode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure invalid marker associated with a sensitive field marker produces error",
+			fields: fields{
+				fieldMarker: testSensitiveMarker,
+				Include:     &includeTrue,
+				Value:       "testSensitive",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -984,3 +1137,148 @@ func TestResourceMarker_setSourceCode(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResourceIncludeCode(t *testing.T) {
+	t.Parallel()
+
+	enabled := &ResourceMarker{
+		excludeCondition: "parent.Spec.Enabled != true",
+	}
+
+	premium := &ResourceMarker{
+		excludeCondition: `parent.Spec.Tier != "premium"`,
+	}
+
+	premiumOr := &ResourceMarker{
+		Operator:         ResourceMarkerOperatorOr,
+		excludeCondition: `parent.Spec.Tier != "premium"`,
+	}
+
+	enterpriseAnd := &ResourceMarker{
+		Operator:         ResourceMarkerOperatorAnd,
+		excludeCondition: `parent.Spec.Tier != "enterprise"`,
+	}
+
+	wave := 1
+	waveOnly := &ResourceMarker{
+		Wave: &wave,
+	}
+
+	tests := []struct {
+		name            string
+		resourceMarkers []*ResourceMarker
+		want            string
+		wantErr         bool
+	}{
+		{
+			name:            "ensure no resource markers produce no include code",
+			resourceMarkers: []*ResourceMarker{},
+			want:            "",
+		},
+		{
+			name:            "ensure a single resource marker produces its include code",
+			resourceMarkers: []*ResourceMarker{enabled},
+			want: `if parent.Spec.Enabled != true {
+		return []client.Object{}, nil
+	}`,
+		},
+		{
+			name:            "ensure resource markers are combined with and by default",
+			resourceMarkers: []*ResourceMarker{enabled, premium},
+			want: `if parent.Spec.Enabled != true || parent.Spec.Tier != "premium" {
+		return []client.Object{}, nil
+	}`,
+		},
+		{
+			name:            "ensure resource markers are combined with or when requested",
+			resourceMarkers: []*ResourceMarker{enabled, premiumOr},
+			want: `if parent.Spec.Enabled != true && parent.Spec.Tier != "premium" {
+		return []client.Object{}, nil
+	}`,
+		},
+		{
+			name:            "ensure resource markers with mismatched operators produce error",
+			resourceMarkers: []*ResourceMarker{premiumOr, enterpriseAnd},
+			wantErr:         true,
+		},
+		{
+			name:            "ensure resource markers which only request a wave produce no include code",
+			resourceMarkers: []*ResourceMarker{waveOnly},
+			want:            "",
+		},
+		{
+			name:            "ensure resource markers which only request a wave are not combined",
+			resourceMarkers: []*ResourceMarker{waveOnly, enabled},
+			want: `if parent.Spec.Enabled != true {
+		return []client.Object{}, nil
+	}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetResourceIncludeCode(tt.resourceMarkers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetResourceIncludeCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceMarker_isUnconditional(t *testing.T) {
+	t.Parallel()
+
+	testField := "test"
+	testInclude := true
+	testWave := 1
+	testCreateOnly := true
+
+	tests := []struct {
+		name   string
+		marker *ResourceMarker
+		want   bool
+	}{
+		{
+			name:   "ensure a marker with only a wave is unconditional",
+			marker: &ResourceMarker{Wave: &testWave},
+			want:   true,
+		},
+		{
+			name:   "ensure a marker with only a deletion policy is unconditional",
+			marker: &ResourceMarker{DeletionPolicy: ResourceMarkerDeletionPolicyRetain},
+			want:   true,
+		},
+		{
+			name:   "ensure a marker with only a policy is unconditional",
+			marker: &ResourceMarker{CreateOnly: &testCreateOnly},
+			want:   true,
+		},
+		{
+			name: "ensure a marker with a wave and a field is not unconditional",
+			marker: &ResourceMarker{
+				Field:   &testField,
+				Value:   true,
+				Include: &testInclude,
+				Wave:    &testWave,
+			},
+			want: false,
+		},
+		{
+			name:   "ensure a marker without a wave or a policy is not unconditional",
+			marker: &ResourceMarker{},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.isUnconditional())
+		})
+	}
+}
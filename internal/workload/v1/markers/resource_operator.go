@@ -0,0 +1,250 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResourceMarkerOperator defines how the conditions of several resource markers on the
+// same resource are combined.
+type ResourceMarkerOperator string
+
+const (
+	ResourceMarkerOperatorAnd ResourceMarkerOperator = "and"
+	ResourceMarkerOperatorOr  ResourceMarkerOperator = "or"
+)
+
+// ResourceMarkerComparison defines how the field of a resource marker is compared against the
+// value of the resource marker.
+type ResourceMarkerComparison string
+
+const (
+	ResourceMarkerCompareEq     ResourceMarkerComparison = "eq"
+	ResourceMarkerCompareNe     ResourceMarkerComparison = "ne"
+	ResourceMarkerCompareGt     ResourceMarkerComparison = "gt"
+	ResourceMarkerCompareLt     ResourceMarkerComparison = "lt"
+	ResourceMarkerCompareIn     ResourceMarkerComparison = "in"
+	ResourceMarkerCompareNotIn  ResourceMarkerComparison = "notIn"
+	ResourceMarkerCompareExists ResourceMarkerComparison = "exists"
+)
+
+// resourceMarkerValueSeparator separates the list of values which are compared against the
+// field of a resource marker with the in and notIn comparisons (e.g. value="gold;platinum").  The
+// separator may not be escaped, so a value which contains it may not be compared with in or notIn.
+const resourceMarkerValueSeparator = ";"
+
+// resourceMarkerComparisons returns the comparisons which may be requested by a resource marker.
+func resourceMarkerComparisons() []ResourceMarkerComparison {
+	return []ResourceMarkerComparison{
+		ResourceMarkerCompareEq,
+		ResourceMarkerCompareNe,
+		ResourceMarkerCompareGt,
+		ResourceMarkerCompareLt,
+		ResourceMarkerCompareIn,
+		ResourceMarkerCompareNotIn,
+		ResourceMarkerCompareExists,
+	}
+}
+
+// UnmarshalMarkerArg will convert the operator argument within a resource marker into
+// its underlying ResourceMarkerOperator object.
+func (o *ResourceMarkerOperator) UnmarshalMarkerArg(in string) error {
+	switch operator := ResourceMarkerOperator(in); operator {
+	case ResourceMarkerOperatorAnd, ResourceMarkerOperatorOr:
+		*o = operator
+
+		return nil
+	default:
+		return fmt.Errorf("%w; %s must be one of [%s, %s]",
+			ErrResourceMarkerInvalidOperator, in, ResourceMarkerOperatorAnd, ResourceMarkerOperatorOr,
+		)
+	}
+}
+
+// String simply returns a ResourceMarkerOperator in string format.
+func (o ResourceMarkerOperator) String() string {
+	if o == "" {
+		return string(ResourceMarkerOperatorAnd)
+	}
+
+	return string(o)
+}
+
+// UnmarshalMarkerArg will convert the compare argument within a resource marker into
+// its underlying ResourceMarkerComparison object.
+func (c *ResourceMarkerComparison) UnmarshalMarkerArg(in string) error {
+	comparisons := resourceMarkerComparisons()
+	names := make([]string, len(comparisons))
+
+	for i, comparison := range comparisons {
+		if ResourceMarkerComparison(in) == comparison {
+			*c = comparison
+
+			return nil
+		}
+
+		names[i] = string(comparison)
+	}
+
+	return fmt.Errorf("%w; %s must be one of [%s]", ErrResourceMarkerInvalidComparison, in, strings.Join(names, ", "))
+}
+
+// String simply returns a ResourceMarkerComparison in string format.
+func (c ResourceMarkerComparison) String() string {
+	if c == "" {
+		return string(ResourceMarkerCompareEq)
+	}
+
+	return string(c)
+}
+
+// getConditions returns the source code conditions under which the comparison requested by
+// the resource marker is met and unmet, ensuring that the comparison and the value are supported
+// by the type of the associated field marker.
+func (rm *ResourceMarker) getConditions() (met, unmet string, err error) {
+	// the value of a sensitive field is not known until the controller resolves it
+	if rm.fieldMarker.IsSensitive() {
+		return "", "", fmt.Errorf("%w; %s", ErrResourceMarkerSensitive, rm)
+	}
+
+	variable := getSourceCodeVariable(rm)
+	fieldType := rm.fieldMarker.GetFieldType()
+	comparison := rm.GetComparison()
+
+	if comparison == ResourceMarkerCompareExists {
+		return rm.getExistsConditions(variable)
+	}
+
+	// optional fields are represented as pointers and may only be checked for existence
+	if rm.fieldMarker.IsOptional() && !fieldType.IsNillable() {
+		return "", "", fmt.Errorf("%w; optional field %s only supports the %s comparison for marker %s",
+			ErrResourceMarkerInvalidComparison, rm.fieldMarker.GetName(), ResourceMarkerCompareExists, rm,
+		)
+	}
+
+	switch comparison {
+	case ResourceMarkerCompareIn, ResourceMarkerCompareNotIn:
+		values, err := rm.getSourceCodeValues()
+		if err != nil {
+			return "", "", err
+		}
+
+		equal := make([]string, len(values))
+		notEqual := make([]string, len(values))
+
+		for i := range values {
+			equal[i] = fmt.Sprintf("%s == %s", variable, values[i])
+			notEqual[i] = fmt.Sprintf("%s != %s", variable, values[i])
+		}
+
+		met, unmet = strings.Join(equal, " || "), strings.Join(notEqual, " && ")
+
+		// wrap the conditions so that they may be combined with the conditions of other markers
+		if len(values) > 1 {
+			met, unmet = "("+met+")", "("+unmet+")"
+		}
+
+		if comparison == ResourceMarkerCompareNotIn {
+			return unmet, met, nil
+		}
+
+		return met, unmet, nil
+	case ResourceMarkerCompareGt, ResourceMarkerCompareLt:
+		if !fieldType.IsInteger() && !fieldType.IsFloat() {
+			return "", "", fmt.Errorf("%w; %s is unsupported for field type %s for marker %s",
+				ErrResourceMarkerInvalidComparison, comparison, fieldType, rm,
+			)
+		}
+	}
+
+	value, err := rm.getSourceCodeValue()
+	if err != nil {
+		return "", "", err
+	}
+
+	switch comparison {
+	case ResourceMarkerCompareNe:
+		return fmt.Sprintf("%s != %s", variable, value), fmt.Sprintf("%s == %s", variable, value), nil
+	case ResourceMarkerCompareGt:
+		return fmt.Sprintf("%s > %s", variable, value), fmt.Sprintf("%s <= %s", variable, value), nil
+	case ResourceMarkerCompareLt:
+		return fmt.Sprintf("%s < %s", variable, value), fmt.Sprintf("%s >= %s", variable, value), nil
+	default:
+		return fmt.Sprintf("%s == %s", variable, value), fmt.Sprintf("%s != %s", variable, value), nil
+	}
+}
+
+// getExistsConditions returns the source code conditions under which the field of the resource
+// marker is set.  Optional fields are set when they are non-nil, while other fields are set when
+// they hold a value other than the zero value of their type.
+func (rm *ResourceMarker) getExistsConditions(variable string) (met, unmet string, err error) {
+	fieldType := rm.fieldMarker.GetFieldType()
+
+	switch {
+	case fieldType.IsNillable():
+		return fmt.Sprintf("len(%s) != 0", variable), fmt.Sprintf("len(%s) == 0", variable), nil
+	case rm.fieldMarker.IsOptional():
+		return fmt.Sprintf("%s != nil", variable), fmt.Sprintf("%s == nil", variable), nil
+	case fieldType == FieldString:
+		return fmt.Sprintf("%s != \"\"", variable), fmt.Sprintf("%s == \"\"", variable), nil
+	case fieldType == FieldBool:
+		return variable, "!" + variable, nil
+	case fieldType.IsInteger(), fieldType.IsFloat():
+		return fmt.Sprintf("%s != 0", variable), fmt.Sprintf("%s == 0", variable), nil
+	default:
+		return "", "", fmt.Errorf("%w; %s is unsupported for field type %s for marker %s",
+			ErrResourceMarkerInvalidComparison, ResourceMarkerCompareExists, fieldType, rm,
+		)
+	}
+}
+
+// getSourceCodeValues returns the list of values of the resource marker as they are compared
+// against the associated field marker in the source code, ensuring that the types match.  The
+// list of values is provided as a single string, separated by semicolons, as the values are
+// converted to the type of the associated field marker.
+func (rm *ResourceMarker) getSourceCodeValues() ([]string, error) {
+	list, ok := rm.Value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w; %s requires a list of values separated by '%s' for marker %s",
+			ErrResourceMarkerUnknownValueType, rm.GetComparison(), resourceMarkerValueSeparator, rm,
+		)
+	}
+
+	fieldType := rm.fieldMarker.GetFieldType()
+
+	values := strings.Split(list, resourceMarkerValueSeparator)
+	sourceCodeValues := make([]string, len(values))
+
+	for i := range values {
+		value := strings.TrimSpace(values[i])
+
+		var err error
+
+		switch {
+		case fieldType == FieldString:
+			sourceCodeValues[i] = fmt.Sprintf("%q", value)
+		case fieldType.IsInteger():
+			_, err = strconv.Atoi(value)
+			sourceCodeValues[i] = value
+		case fieldType.IsFloat():
+			_, err = strconv.ParseFloat(value, 64)
+			sourceCodeValues[i] = value
+		default:
+			return nil, fmt.Errorf("%w; %s is unsupported for field type %s for marker %s",
+				ErrResourceMarkerInvalidComparison, rm.GetComparison(), fieldType, rm,
+			)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w; expected: %s, got: %q for marker %s",
+				ErrResourceMarkerTypeMismatch, fieldType, value, rm,
+			)
+		}
+	}
+
+	return sourceCodeValues, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMarkerOperator_UnmarshalMarkerArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    ResourceMarkerOperator
+		wantErr bool
+	}{
+		{
+			name: "ensure and operator is unmarshaled",
+			in:   "and",
+			want: ResourceMarkerOperatorAnd,
+		},
+		{
+			name: "ensure or operator is unmarshaled",
+			in:   "or",
+			want: ResourceMarkerOperatorOr,
+		},
+		{
+			name:    "ensure unknown operator produces error",
+			in:      "xor",
+			wantErr: true,
+		},
+		{
+			name:    "ensure comparison requested as an operator produces error",
+			in:      "gt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var operator ResourceMarkerOperator
+			if err := operator.UnmarshalMarkerArg(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("ResourceMarkerOperator.UnmarshalMarkerArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, operator)
+		})
+	}
+}
+
+func TestResourceMarkerComparison_UnmarshalMarkerArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    ResourceMarkerComparison
+		wantErr bool
+	}{
+		{
+			name: "ensure gt comparison is unmarshaled",
+			in:   "gt",
+			want: ResourceMarkerCompareGt,
+		},
+		{
+			name: "ensure notIn comparison is unmarshaled",
+			in:   "notIn",
+			want: ResourceMarkerCompareNotIn,
+		},
+		{
+			name:    "ensure unknown comparison produces error",
+			in:      "ge",
+			wantErr: true,
+		},
+		{
+			name:    "ensure operator requested as a comparison produces error",
+			in:      "or",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var comparison ResourceMarkerComparison
+			if err := comparison.UnmarshalMarkerArg(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("ResourceMarkerComparison.UnmarshalMarkerArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, comparison)
+		})
+	}
+}
+
+func TestResourceMarker_getConditions(t *testing.T) {
+	t.Parallel()
+
+	testField := "test"
+	testOptional := true
+
+	testIntMarker := &FieldMarker{Name: testField, Type: FieldInt}
+	testFloatMarker := &FieldMarker{Name: testField, Type: FieldFloat64}
+	testStringMarker := &FieldMarker{Name: testField, Type: FieldString}
+	testBoolMarker := &FieldMarker{Name: testField, Type: FieldBool}
+	testMapMarker := &FieldMarker{Name: testField, Type: FieldStringMap}
+	testOptionalMarker := &FieldMarker{Name: testField, Type: FieldString, Optional: &testOptional}
+
+	tests := []struct {
+		name        string
+		comparison  ResourceMarkerComparison
+		value       interface{}
+		fieldMarker FieldMarkerProcessor
+		wantMet     string
+		wantUnmet   string
+		wantErr     bool
+	}{
+		{
+			name:        "ensure eq is the default comparison",
+			value:       "aws",
+			fieldMarker: testStringMarker,
+			wantMet:     `parent.Spec.Test == "aws"`,
+			wantUnmet:   `parent.Spec.Test != "aws"`,
+		},
+		{
+			name:        "ensure ne comparison produces inequality conditions",
+			comparison:  ResourceMarkerCompareNe,
+			value:       "aws",
+			fieldMarker: testStringMarker,
+			wantMet:     `parent.Spec.Test != "aws"`,
+			wantUnmet:   `parent.Spec.Test == "aws"`,
+		},
+		{
+			name:        "ensure gt comparison produces greater than conditions",
+			comparison:  ResourceMarkerCompareGt,
+			value:       1,
+			fieldMarker: testIntMarker,
+			wantMet:     "parent.Spec.Test > 1",
+			wantUnmet:   "parent.Spec.Test <= 1",
+		},
+		{
+			name:        "ensure lt comparison produces less than conditions",
+			comparison:  ResourceMarkerCompareLt,
+			value:       1.5,
+			fieldMarker: testFloatMarker,
+			wantMet:     "parent.Spec.Test < 1.5",
+			wantUnmet:   "parent.Spec.Test >= 1.5",
+		},
+		{
+			name:        "ensure gt comparison with a string field produces error",
+			comparison:  ResourceMarkerCompareGt,
+			value:       "aws",
+			fieldMarker: testStringMarker,
+			wantErr:     true,
+		},
+		{
+			name:        "ensure in comparison produces conditions for each value",
+			comparison:  ResourceMarkerCompareIn,
+			value:       "gold; platinum",
+			fieldMarker: testStringMarker,
+			wantMet:     `(parent.Spec.Test == "gold" || parent.Spec.Test == "platinum")`,
+			wantUnmet:   `(parent.Spec.Test != "gold" && parent.Spec.Test != "platinum")`,
+		},
+		{
+			name:        "ensure notIn comparison produces conditions for each value",
+			comparison:  ResourceMarkerCompareNotIn,
+			value:       "1;2",
+			fieldMarker: testIntMarker,
+			wantMet:     "(parent.Spec.Test != 1 && parent.Spec.Test != 2)",
+			wantUnmet:   "(parent.Spec.Test == 1 || parent.Spec.Test == 2)",
+		},
+		{
+			name:        "ensure in comparison with a single value produces unwrapped conditions",
+			comparison:  ResourceMarkerCompareIn,
+			value:       "gold",
+			fieldMarker: testStringMarker,
+			wantMet:     `parent.Spec.Test == "gold"`,
+			wantUnmet:   `parent.Spec.Test != "gold"`,
+		},
+		{
+			name:        "ensure in comparison with a mismatched value produces error",
+			comparison:  ResourceMarkerCompareIn,
+			value:       "1;two",
+			fieldMarker: testIntMarker,
+			wantErr:     true,
+		},
+		{
+			name:        "ensure in comparison with a non-list value produces error",
+			comparison:  ResourceMarkerCompareIn,
+			value:       1,
+			fieldMarker: testIntMarker,
+			wantErr:     true,
+		},
+		{
+			name:        "ensure in comparison with a bool field produces error",
+			comparison:  ResourceMarkerCompareIn,
+			value:       "true",
+			fieldMarker: testBoolMarker,
+			wantErr:     true,
+		},
+		{
+			name:        "ensure exists comparison with a string field checks for the zero value",
+			comparison:  ResourceMarkerCompareExists,
+			fieldMarker: testStringMarker,
+			wantMet:     `parent.Spec.Test != ""`,
+			wantUnmet:   `parent.Spec.Test == ""`,
+		},
+		{
+			name:        "ensure exists comparison with a bool field checks the value",
+			comparison:  ResourceMarkerCompareExists,
+			fieldMarker: testBoolMarker,
+			wantMet:     "parent.Spec.Test",
+			wantUnmet:   "!parent.Spec.Test",
+		},
+		{
+			name:        "ensure exists comparison with a map field checks the length",
+			comparison:  ResourceMarkerCompareExists,
+			fieldMarker: testMapMarker,
+			wantMet:     "len(parent.Spec.Test) != 0",
+			wantUnmet:   "len(parent.Spec.Test) == 0",
+		},
+		{
+			name:        "ensure exists comparison with an optional field checks for nil",
+			comparison:  ResourceMarkerCompareExists,
+			fieldMarker: testOptionalMarker,
+			wantMet:     "parent.Spec.Test != nil",
+			wantUnmet:   "parent.Spec.Test == nil",
+		},
+		{
+			name:        "ensure eq comparison with an optional field produces error",
+			value:       "aws",
+			fieldMarker: testOptionalMarker,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rm := &ResourceMarker{
+				Field:       &testField,
+				Value:       tt.value,
+				Compare:     tt.comparison,
+				fieldMarker: tt.fieldMarker,
+			}
+
+			met, unmet, err := rm.getConditions()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResourceMarker.getConditions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.wantMet, met)
+			assert.Equal(t, tt.wantUnmet, unmet)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceMarkerDeletionPolicy defines what happens to the resource of a resource marker when
+// the parent custom resource is deleted.
+type ResourceMarkerDeletionPolicy string
+
+const (
+	// ResourceMarkerDeletionPolicyDelete deletes the resource along with the custom resource.
+	ResourceMarkerDeletionPolicyDelete ResourceMarkerDeletionPolicy = "delete"
+
+	// ResourceMarkerDeletionPolicyOrphan leaves the resource in the cluster, without an owner.
+	ResourceMarkerDeletionPolicyOrphan ResourceMarkerDeletionPolicy = "orphan"
+
+	// ResourceMarkerDeletionPolicyRetain leaves the resource in the cluster, without an owner, and
+	// annotates it with the custom resource from which it was retained.
+	ResourceMarkerDeletionPolicyRetain ResourceMarkerDeletionPolicy = "retain"
+)
+
+// ResourceMarkerIgnoreFieldSeparator separates the paths of the fields which are requested by
+// the ignoreFields argument of a resource marker (e.g. ignoreFields="spec.replicas;spec.paused").
+const ResourceMarkerIgnoreFieldSeparator = ";"
+
+// fieldPathRegex matches the dot-separated path of a field within a resource.  A dot within a key
+// is escaped by a backslash (e.g. metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration).
+var fieldPathRegex = regexp.MustCompile(`^([A-Za-z0-9_/-]|\\\.)+(\.([A-Za-z0-9_/-]|\\\.)+)*$`)
+
+// ignoreFieldIdentity are the paths of the fields which identify a resource and may therefore
+// never be ignored.
+var ignoreFieldIdentity = map[string]bool{
+	"apiVersion":         true,
+	"kind":               true,
+	"metadata":           true,
+	"metadata.name":      true,
+	"metadata.namespace": true,
+}
+
+// UnmarshalMarkerArg will convert the deletionPolicy argument within a resource marker into
+// its underlying ResourceMarkerDeletionPolicy object.
+func (p *ResourceMarkerDeletionPolicy) UnmarshalMarkerArg(in string) error {
+	switch policy := ResourceMarkerDeletionPolicy(in); policy {
+	case ResourceMarkerDeletionPolicyDelete, ResourceMarkerDeletionPolicyOrphan, ResourceMarkerDeletionPolicyRetain:
+		*p = policy
+
+		return nil
+	default:
+		return fmt.Errorf("%w; %s must be one of [%s, %s, %s]",
+			ErrResourceMarkerInvalidDeletionPolicy, in,
+			ResourceMarkerDeletionPolicyDelete, ResourceMarkerDeletionPolicyOrphan, ResourceMarkerDeletionPolicyRetain,
+		)
+	}
+}
+
+// String simply returns a ResourceMarkerDeletionPolicy in string format.
+func (p ResourceMarkerDeletionPolicy) String() string {
+	if p == "" {
+		return string(ResourceMarkerDeletionPolicyDelete)
+	}
+
+	return string(p)
+}
+
+// GetCreateOnly is a convenience function to return whether the resource marker requests that
+// its resource is created once and never updated.
+func (rm *ResourceMarker) GetCreateOnly() bool {
+	if rm.CreateOnly == nil {
+		return false
+	}
+
+	return *rm.CreateOnly
+}
+
+// GetIgnoreFields is a convenience function to return the paths of the fields which the resource
+// marker requests are ignored when its resource is updated.
+func (rm *ResourceMarker) GetIgnoreFields() []string {
+	if rm.IgnoreFields == nil {
+		return []string{}
+	}
+
+	fields := []string{}
+
+	for _, field := range strings.Split(*rm.IgnoreFields, ResourceMarkerIgnoreFieldSeparator) {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// validatePolicy checks for a valid policy on a resource marker and returns an error if the
+// policy is invalid.
+func (rm *ResourceMarker) validatePolicy() error {
+	if rm.IgnoreFields == nil {
+		return nil
+	}
+
+	fields := rm.GetIgnoreFields()
+	if len(fields) == 0 {
+		return fmt.Errorf("%w; no fields requested for marker %s", ErrResourceMarkerInvalidIgnoreField, rm)
+	}
+
+	for _, field := range fields {
+		if !fieldPathRegex.MatchString(field) {
+			return fmt.Errorf("%w; field %q is not a dot-separated path for marker %s",
+				ErrResourceMarkerInvalidIgnoreField, field, rm,
+			)
+		}
+
+		if ignoreFieldIdentity[field] {
+			return fmt.Errorf("%w; field %q identifies the resource for marker %s",
+				ErrResourceMarkerInvalidIgnoreField, field, rm,
+			)
+		}
+	}
+
+	if rm.GetCreateOnly() {
+		return fmt.Errorf("%w for marker %s", ErrResourceMarkerPolicyConflict, rm)
+	}
+
+	return nil
+}
+
+// GetResourcePolicy returns the policy requested by a set of processed resource markers which were
+// found on the same resource.  A resource is created once and never updated when any marker
+// requests it, while the fields which are ignored are those requested by all of the markers.  A
+// resource which is never updated has no need to ignore fields, so the policies may not be combined.
+func GetResourcePolicy(resourceMarkers []*ResourceMarker) (createOnly bool, ignoreFields []string, err error) {
+	ignoreFields = []string{}
+	requested := map[string]bool{}
+
+	for _, rm := range resourceMarkers {
+		createOnly = createOnly || rm.GetCreateOnly()
+
+		for _, field := range rm.GetIgnoreFields() {
+			if !requested[field] {
+				requested[field] = true
+				ignoreFields = append(ignoreFields, field)
+			}
+		}
+	}
+
+	if createOnly && len(ignoreFields) > 0 {
+		return false, []string{}, fmt.Errorf("%w; requested fields %s",
+			ErrResourceMarkerPolicyConflict, strings.Join(ignoreFields, ", "),
+		)
+	}
+
+	return createOnly, ignoreFields, nil
+}
+
+// GetResourceDeletionPolicy returns the deletion policy requested by a set of processed resource
+// markers which were found on the same resource.  Markers which do not request a deletion policy
+// defer to the deletion policy requested by the other markers, however all markers which request
+// a deletion policy must request the same one.
+func GetResourceDeletionPolicy(resourceMarkers []*ResourceMarker) (ResourceMarkerDeletionPolicy, error) {
+	var policy ResourceMarkerDeletionPolicy
+
+	for _, rm := range resourceMarkers {
+		if rm.DeletionPolicy == "" {
+			continue
+		}
+
+		if policy != "" && policy != rm.DeletionPolicy {
+			return "", fmt.Errorf("%w; %s and %s requested for marker %s",
+				ErrResourceMarkerDeletionPolicyMismatch, policy, rm.DeletionPolicy, rm,
+			)
+		}
+
+		policy = rm.DeletionPolicy
+	}
+
+	if policy == "" {
+		return ResourceMarkerDeletionPolicyDelete, nil
+	}
+
+	return policy, nil
+}
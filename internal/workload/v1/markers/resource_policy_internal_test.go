@@ -0,0 +1,261 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMarker_GetIgnoreFields(t *testing.T) {
+	t.Parallel()
+
+	testIgnoreFields := func(fields string) *string { return &fields }
+
+	tests := []struct {
+		name   string
+		marker *ResourceMarker
+		want   []string
+	}{
+		{
+			name:   "ensure no fields are ignored by default",
+			marker: &ResourceMarker{},
+			want:   []string{},
+		},
+		{
+			name:   "ensure a single field is returned",
+			marker: &ResourceMarker{IgnoreFields: testIgnoreFields("spec.replicas")},
+			want:   []string{"spec.replicas"},
+		},
+		{
+			name:   "ensure multiple fields are split and trimmed",
+			marker: &ResourceMarker{IgnoreFields: testIgnoreFields("spec.replicas; spec.paused;")},
+			want:   []string{"spec.replicas", "spec.paused"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.GetIgnoreFields())
+		})
+	}
+}
+
+func TestResourceMarker_validatePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCreateOnly := true
+	testIgnoreFields := func(fields string) *string { return &fields }
+
+	tests := []struct {
+		name    string
+		marker  *ResourceMarker
+		wantErr bool
+	}{
+		{
+			name:   "ensure a marker without a policy is valid",
+			marker: &ResourceMarker{},
+		},
+		{
+			name:   "ensure a create only policy is valid",
+			marker: &ResourceMarker{CreateOnly: &testCreateOnly},
+		},
+		{
+			name:   "ensure an ignore fields policy with nested fields is valid",
+			marker: &ResourceMarker{IgnoreFields: testIgnoreFields("spec.replicas;metadata.labels.app")},
+		},
+		{
+			name: "ensure an ignore fields policy with an escaped dotted key is valid",
+			marker: &ResourceMarker{
+				IgnoreFields: testIgnoreFields(`metadata.annotations.kubectl\.kubernetes\.io/last-applied-configuration`),
+			},
+		},
+		{
+			name:    "ensure an ignore fields policy with an unescaped backslash produces error",
+			marker:  &ResourceMarker{IgnoreFields: testIgnoreFields(`metadata.annotations.kubectl\kubernetes`)},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an ignore fields policy without fields produces error",
+			marker:  &ResourceMarker{IgnoreFields: testIgnoreFields(" ; ")},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an ignore fields policy with an invalid path produces error",
+			marker:  &ResourceMarker{IgnoreFields: testIgnoreFields("spec..replicas")},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an ignore fields policy with an indexed path produces error",
+			marker:  &ResourceMarker{IgnoreFields: testIgnoreFields("spec.containers[0].image")},
+			wantErr: true,
+		},
+		{
+			name:    "ensure an ignore fields policy with an identifying field produces error",
+			marker:  &ResourceMarker{IgnoreFields: testIgnoreFields("metadata.name")},
+			wantErr: true,
+		},
+		{
+			name: "ensure a create only and an ignore fields policy produce error",
+			marker: &ResourceMarker{
+				CreateOnly:   &testCreateOnly,
+				IgnoreFields: testIgnoreFields("spec.replicas"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.marker.validatePolicy(); (err != nil) != tt.wantErr {
+				t.Errorf("ResourceMarker.validatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResourcePolicy(t *testing.T) {
+	t.Parallel()
+
+	testCreateOnly := true
+	testNotCreateOnly := false
+	testReplicas := "spec.replicas"
+	testFields := "spec.paused;spec.replicas"
+
+	tests := []struct {
+		name             string
+		resourceMarkers  []*ResourceMarker
+		wantCreateOnly   bool
+		wantIgnoreFields []string
+		wantErr          bool
+	}{
+		{
+			name:             "ensure no resource markers produce no policy",
+			resourceMarkers:  []*ResourceMarker{},
+			wantIgnoreFields: []string{},
+		},
+		{
+			name:             "ensure any marker may request create only",
+			resourceMarkers:  []*ResourceMarker{{CreateOnly: &testNotCreateOnly}, {CreateOnly: &testCreateOnly}},
+			wantCreateOnly:   true,
+			wantIgnoreFields: []string{},
+		},
+		{
+			name:             "ensure the ignored fields of all markers are combined without duplicates",
+			resourceMarkers:  []*ResourceMarker{{IgnoreFields: &testReplicas}, {IgnoreFields: &testFields}},
+			wantIgnoreFields: []string{"spec.replicas", "spec.paused"},
+		},
+		{
+			name:             "ensure create only and ignored fields from separate markers produce error",
+			resourceMarkers:  []*ResourceMarker{{CreateOnly: &testCreateOnly}, {IgnoreFields: &testReplicas}},
+			wantIgnoreFields: []string{},
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			createOnly, ignoreFields, err := GetResourcePolicy(tt.resourceMarkers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetResourcePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.wantCreateOnly, createOnly)
+			assert.Equal(t, tt.wantIgnoreFields, ignoreFields)
+		})
+	}
+}
+
+func TestResourceMarkerDeletionPolicy_UnmarshalMarkerArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    ResourceMarkerDeletionPolicy
+		wantErr bool
+	}{
+		{
+			name: "ensure retain deletion policy is unmarshaled",
+			in:   "retain",
+			want: ResourceMarkerDeletionPolicyRetain,
+		},
+		{
+			name: "ensure orphan deletion policy is unmarshaled",
+			in:   "orphan",
+			want: ResourceMarkerDeletionPolicyOrphan,
+		},
+		{
+			name:    "ensure unknown deletion policy produces error",
+			in:      "keep",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var policy ResourceMarkerDeletionPolicy
+			if err := policy.UnmarshalMarkerArg(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("ResourceMarkerDeletionPolicy.UnmarshalMarkerArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+func TestGetResourceDeletionPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		resourceMarkers []*ResourceMarker
+		want            ResourceMarkerDeletionPolicy
+		wantErr         bool
+	}{
+		{
+			name:            "ensure no resource markers produce the delete policy",
+			resourceMarkers: []*ResourceMarker{},
+			want:            ResourceMarkerDeletionPolicyDelete,
+		},
+		{
+			name: "ensure markers without a deletion policy defer to the requested policy",
+			resourceMarkers: []*ResourceMarker{
+				{},
+				{DeletionPolicy: ResourceMarkerDeletionPolicyRetain},
+			},
+			want: ResourceMarkerDeletionPolicyRetain,
+		},
+		{
+			name: "ensure markers with mismatched deletion policies produce error",
+			resourceMarkers: []*ResourceMarker{
+				{DeletionPolicy: ResourceMarkerDeletionPolicyRetain},
+				{DeletionPolicy: ResourceMarkerDeletionPolicyOrphan},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetResourceDeletionPolicy(tt.resourceMarkers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetResourceDeletionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import "fmt"
+
+// GetWave is a convenience function to return the wave requested by the resource marker.  Resources
+// are created in the first wave unless another wave is requested.
+func (rm *ResourceMarker) GetWave() int {
+	if rm.Wave == nil {
+		return 0
+	}
+
+	return *rm.Wave
+}
+
+// GetResourceWave returns the wave requested by a set of processed resource markers which were
+// found on the same resource.  Markers which do not request a wave defer to the wave requested
+// by the other markers, however all markers which request a wave must request the same one.
+func GetResourceWave(resourceMarkers []*ResourceMarker) (int, error) {
+	var wave *int
+
+	for _, rm := range resourceMarkers {
+		if rm.Wave == nil {
+			continue
+		}
+
+		if wave != nil && *wave != *rm.Wave {
+			return 0, fmt.Errorf("%w; %d and %d requested for marker %s",
+				ErrResourceMarkerWaveMismatch, *wave, *rm.Wave, rm,
+			)
+		}
+
+		wave = rm.Wave
+	}
+
+	if wave == nil {
+		return 0, nil
+	}
+
+	return *wave, nil
+}
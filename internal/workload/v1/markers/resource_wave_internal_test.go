@@ -0,0 +1,89 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMarker_GetWave(t *testing.T) {
+	t.Parallel()
+
+	testWave := 2
+
+	tests := []struct {
+		name   string
+		marker *ResourceMarker
+		want   int
+	}{
+		{
+			name:   "ensure the first wave is the default wave",
+			marker: &ResourceMarker{},
+			want:   0,
+		},
+		{
+			name:   "ensure the requested wave is returned",
+			marker: &ResourceMarker{Wave: &testWave},
+			want:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.GetWave())
+		})
+	}
+}
+
+func TestGetResourceWave(t *testing.T) {
+	t.Parallel()
+
+	firstWave := 1
+	secondWave := 2
+
+	tests := []struct {
+		name            string
+		resourceMarkers []*ResourceMarker
+		want            int
+		wantErr         bool
+	}{
+		{
+			name:            "ensure no resource markers produce the first wave",
+			resourceMarkers: []*ResourceMarker{},
+			want:            0,
+		},
+		{
+			name:            "ensure markers without a wave defer to the requested wave",
+			resourceMarkers: []*ResourceMarker{{}, {Wave: &secondWave}},
+			want:            2,
+		},
+		{
+			name:            "ensure markers which request the same wave produce the wave",
+			resourceMarkers: []*ResourceMarker{{Wave: &firstWave}, {Wave: &firstWave}},
+			want:            1,
+		},
+		{
+			name:            "ensure markers with mismatched waves produce error",
+			resourceMarkers: []*ResourceMarker{{Wave: &firstWave}, {Wave: &secondWave}},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetResourceWave(tt.resourceMarkers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetResourceWave() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/inspect"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrStatusMarkerReserved    = errors.New("status marker name is reserved for internal purposes")
+	ErrStatusMarkerInvalidName = errors.New("status marker name is invalid")
+	ErrStatusMarkerInvalidPath = errors.New("status marker path is invalid")
+)
+
+const StatusMarkerPrefix = "+operator-builder:status"
+
+// StatusMarker is an object which represents a marker that projects the value of a field
+// from a live child resource into the status of the parent custom resource.  A StatusMarker
+// is discovered when a manifest is parsed and matches the constants defined by the
+// statusMarker constant above.
+type StatusMarker struct {
+	// inputs from the marker itself
+	Name        string
+	Type        FieldType
+	Description *string
+	Path        *string
+
+	// other values which we use to pass information
+	keyNode    *yaml.Node
+	sourcePath []string
+}
+
+//nolint:gocritic //needed to implement string interface
+func (sm StatusMarker) String() string {
+	return fmt.Sprintf("StatusMarker{Name: %s Type: %v Description: %q Path: %q}",
+		sm.Name,
+		sm.Type,
+		sm.GetDescription(),
+		sm.GetPath(),
+	)
+}
+
+// defineStatusMarker will define a StatusMarker and add it a registry of markers.
+func defineStatusMarker(registry *marker.Registry) error {
+	statusMarker, err := marker.Define(StatusMarkerPrefix, StatusMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(statusMarker)
+
+	return nil
+}
+
+// reservedStatusFields represents a list of status fields which are always generated
+// for a custom resource and may not be requested by a status marker.
+func reservedStatusFields() []string {
+	return []string{
+		"created",
+		"dependenciesSatisfied",
+		"conditions",
+		"resources",
+		"readyConditions",
+	}
+}
+
+// GetName returns the name of the status field.
+func (sm *StatusMarker) GetName() string {
+	return sm.Name
+}
+
+// GetFieldName returns the name of the status field as it is represented in the
+// source code.
+func (sm *StatusMarker) GetFieldName() string {
+	return strings.Title(sm.Name)
+}
+
+// GetDescription returns the description of the status field.
+func (sm *StatusMarker) GetDescription() string {
+	if sm.Description == nil {
+		return ""
+	}
+
+	return *sm.Description
+}
+
+// GetComments returns the description of the status field as a set of comment lines.
+func (sm *StatusMarker) GetComments() []string {
+	if sm.GetDescription() == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimPrefix(sm.GetDescription(), "\n"), "\n")
+}
+
+// GetPath returns the path requested by the marker itself.
+func (sm *StatusMarker) GetPath() string {
+	if sm.Path == nil {
+		return ""
+	}
+
+	return *sm.Path
+}
+
+// GetSourcePath returns the path of the field, within the child resource, from which the
+// status field is projected.
+func (sm *StatusMarker) GetSourcePath() []string {
+	return sm.sourcePath
+}
+
+// GetSourcePathCode returns the path of the field, within the child resource, as a list of
+// quoted strings for use in the source code.
+func (sm *StatusMarker) GetSourcePathCode() string {
+	quoted := make([]string, len(sm.sourcePath))
+
+	for i := range sm.sourcePath {
+		quoted[i] = fmt.Sprintf("%q", sm.sourcePath[i])
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// validate checks for a valid status marker and returns an error if the status marker
+// is invalid.
+func (sm *StatusMarker) validate() error {
+	if sm.Name == "" || strings.Contains(sm.Name, ".") {
+		return fmt.Errorf("%w; %s must be a non-empty name without a '.'", ErrStatusMarkerInvalidName, sm)
+	}
+
+	for _, reserved := range reservedStatusFields() {
+		if strings.EqualFold(sm.Name, reserved) {
+			return fmt.Errorf("%w; %s", ErrStatusMarkerReserved, sm)
+		}
+	}
+
+	return nil
+}
+
+// SetSourcePath sets the path of the field from which the status field is projected.  The
+// path requested by the marker itself takes precedence, otherwise the path of the key which
+// the marker was placed upon is found within the document.
+func (sm *StatusMarker) SetSourcePath(document *yaml.Node) error {
+	if sm.GetPath() != "" {
+		sm.sourcePath = strings.Split(sm.GetPath(), ".")
+
+		for _, part := range sm.sourcePath {
+			if part == "" {
+				return fmt.Errorf("%w; %s contains an empty path segment", ErrStatusMarkerInvalidPath, sm)
+			}
+		}
+
+		return nil
+	}
+
+	if sm.keyNode == nil {
+		return fmt.Errorf("%w; %s must be placed on a mapping key or request a path", ErrStatusMarkerInvalidPath, sm)
+	}
+
+	sourcePath, err := findKeyPath(document, sm.keyNode)
+	if err != nil {
+		return fmt.Errorf("%w; %s", err, sm)
+	}
+
+	if sourcePath == nil {
+		return fmt.Errorf("%w; %s was not found within the document", ErrStatusMarkerInvalidPath, sm)
+	}
+
+	sm.sourcePath = sourcePath
+
+	return nil
+}
+
+// transformStatus will transform a YAML result for a status marker.  The value is left as
+// is, while the key is stored so that the path of the field may be found later.
+func transformStatus(marker *StatusMarker, result *inspect.YAMLResult) error {
+	if err := marker.validate(); err != nil {
+		return err
+	}
+
+	if len(result.Nodes) > 1 {
+		marker.keyNode = result.Nodes[0]
+	}
+
+	return nil
+}
+
+// findKeyPath finds the path of keys from a node to a target key node.  A nil path is returned
+// if the target is not found.  Sequences are not supported as their items may not be addressed
+// by a path of keys.
+func findKeyPath(node, target *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			if path, err := findKeyPath(content, target); path != nil || err != nil {
+				return path, err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if key == target {
+				return []string{key.Value}, nil
+			}
+
+			path, err := findKeyPath(value, target)
+			if err != nil {
+				return nil, err
+			}
+
+			if path != nil {
+				return append([]string{key.Value}, path...), nil
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if path, err := findKeyPath(item, target); path != nil || err != nil {
+				return nil, fmt.Errorf("%w; fields within a sequence are unsupported", ErrStatusMarkerInvalidPath)
+			}
+		}
+	}
+
+	return nil, nil
+}
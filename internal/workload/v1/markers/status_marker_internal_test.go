@@ -0,0 +1,172 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMarker_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		marker  *StatusMarker
+		wantErr bool
+	}{
+		{
+			name:    "valid status marker",
+			marker:  &StatusMarker{Name: "clusterIP", Type: FieldString},
+			wantErr: false,
+		},
+		{
+			name:    "status marker with empty name",
+			marker:  &StatusMarker{Name: "", Type: FieldString},
+			wantErr: true,
+		},
+		{
+			name:    "status marker with nested name",
+			marker:  &StatusMarker{Name: "service.clusterIP", Type: FieldString},
+			wantErr: true,
+		},
+		{
+			name:    "status marker with reserved name",
+			marker:  &StatusMarker{Name: "Conditions", Type: FieldString},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.marker.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("StatusMarker.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatusMarker_SetSourcePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "status marker on a nested key",
+			manifest: `
+kind: Service
+spec:
+  # +operator-builder:status:name=selector,type=map[string]string
+  selector:
+    app: webstore
+`,
+			want: []string{"spec", "selector"},
+		},
+		{
+			name: "status marker on a value",
+			manifest: `
+kind: Service
+spec:
+  type: ClusterIP  # +operator-builder:status:name=serviceType,type=string
+`,
+			want: []string{"spec", "type"},
+		},
+		{
+			name: "status marker with a path",
+			manifest: `
+kind: Service
+# +operator-builder:status:name=clusterIP,type=string,path="spec.clusterIP"
+spec:
+  type: ClusterIP
+`,
+			want: []string{"spec", "clusterIP"},
+		},
+		{
+			name: "status marker with an empty path segment",
+			manifest: `
+kind: Service
+# +operator-builder:status:name=clusterIP,type=string,path="spec..clusterIP"
+spec:
+  type: ClusterIP
+`,
+			wantErr: true,
+		},
+		{
+			name: "status marker within a sequence",
+			manifest: `
+kind: Service
+spec:
+  ports:
+  - port: 80  # +operator-builder:status:name=port,type=int
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			nodes, results, err := InspectForYAML([]byte(tt.manifest), StatusMarkerType)
+			if err != nil {
+				t.Fatalf("InspectForYAML() error = %v", err)
+			}
+
+			if !assert.Len(t, results, 1) {
+				return
+			}
+
+			marker, ok := results[0].Object.(*StatusMarker)
+			if !assert.True(t, ok) {
+				return
+			}
+
+			if err := marker.SetSourcePath(nodes[0]); (err != nil) != tt.wantErr {
+				t.Errorf("StatusMarker.SetSourcePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want, marker.GetSourcePath())
+		})
+	}
+}
+
+func TestStatusMarker_GetSourcePathCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		marker *StatusMarker
+		want   string
+	}{
+		{
+			name:   "status marker with a nested source path",
+			marker: &StatusMarker{sourcePath: []string{"status", "loadBalancer", "ingress"}},
+			want:   `"status", "loadBalancer", "ingress"`,
+		},
+		{
+			name:   "status marker with a single source path",
+			marker: &StatusMarker{sourcePath: []string{"data"}},
+			want:   `"data"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.marker.GetSourcePathCode())
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/markers/marker"
+)
+
+var (
+	ErrTemplateMarkerInvalid          = errors.New("template marker is invalid")
+	ErrTemplateMarkerUnknownReference = errors.New("template marker references an unknown field")
+	ErrTemplateMarkerInvalidReference = errors.New("template marker references a field which cannot be rendered as a string")
+)
+
+const (
+	TemplateMarkerPrefix = "+operator-builder:template"
+
+	// templateCollectionPrefix is the prefix of a template reference which refers to a
+	// field of the collection rather than a field of the workload itself.
+	templateCollectionPrefix = "collection."
+
+	// templateRepeatPrefix is the prefix of a template reference which refers to the index
+	// or the item of the current copy of a resource with a repeat marker.
+	templateRepeatPrefix = "repeat."
+	templateRepeatIndex  = "index"
+	templateRepeatItem   = "item"
+)
+
+// templateReferenceRegex matches the field references within the value of a template
+// marker (e.g. {{ .appName }} or {{ .collection.domain }}).
+var templateReferenceRegex = regexp.MustCompile(`{{\s*\.([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)\s*}}`)
+
+// TemplateMarker is an object which represents a marker that builds the value of a field
+// within a manifest from several field markers and collection field markers.  A TemplateMarker
+// is discovered when a manifest is parsed and matches the constants defined by the
+// templateMarker constant above.
+type TemplateMarker struct {
+	// inputs from the marker itself
+	Value string
+
+	// other values which we use to pass information
+	references         []*TemplateReference
+	sourceCode         string
+	collectionIsParent bool
+}
+
+// TemplateReference represents a reference to a field marker, a collection field marker, or
+// the loop variables of a repeated resource, from the value of a template marker.
+type TemplateReference struct {
+	Name       string
+	Collection bool
+	Repeat     bool
+}
+
+//nolint:gocritic //needed to implement string interface
+func (tm TemplateMarker) String() string {
+	return fmt.Sprintf("TemplateMarker{Value: %q}", tm.Value)
+}
+
+// defineTemplateMarker will define a TemplateMarker and add it a registry of markers.
+func defineTemplateMarker(registry *marker.Registry) error {
+	templateMarker, err := marker.Define(TemplateMarkerPrefix, TemplateMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(templateMarker)
+
+	return nil
+}
+
+// GetReferences returns the field references found within the value of a template marker.
+func (tm *TemplateMarker) GetReferences() []*TemplateReference {
+	return tm.references
+}
+
+// GetSourceCode returns the source code which builds the value of a template marker.
+func (tm *TemplateMarker) GetSourceCode() string {
+	return tm.sourceCode
+}
+
+// setSourceCode parses the value of a template marker and sets the source code which
+// builds the string value from the referenced fields.
+func (tm *TemplateMarker) setSourceCode() error {
+	matches := templateReferenceRegex.FindAllStringSubmatchIndex(tm.Value, -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("%w; %s must reference at least one field", ErrTemplateMarkerInvalid, tm)
+	}
+
+	var format strings.Builder
+
+	variables := make([]string, len(matches))
+	tm.references = make([]*TemplateReference, len(matches))
+
+	var last int
+
+	for i, match := range matches {
+		if err := tm.writeLiteral(&format, tm.Value[last:match[0]]); err != nil {
+			return err
+		}
+
+		format.WriteString("%v")
+
+		reference := newTemplateReference(tm.Value[match[2]:match[3]])
+
+		// the fields of a collection are the fields of the parent within its own manifests
+		if tm.collectionIsParent {
+			reference.Collection = false
+		}
+
+		if reference.Repeat && reference.GetSourceCodeVariable() == "" {
+			return fmt.Errorf("%w; %s for %s must be one of [%s%s, %s%s]",
+				ErrTemplateMarkerUnknownReference, reference, tm,
+				templateRepeatPrefix, templateRepeatIndex, templateRepeatPrefix, templateRepeatItem,
+			)
+		}
+
+		tm.references[i] = reference
+		variables[i] = reference.GetSourceCodeVariable()
+
+		last = match[1]
+	}
+
+	if err := tm.writeLiteral(&format, tm.Value[last:]); err != nil {
+		return err
+	}
+
+	tm.sourceCode = fmt.Sprintf("fmt.Sprintf(%q, %s)", format.String(), strings.Join(variables, ", "))
+
+	return nil
+}
+
+// getLoopVariable returns the loop variable of a repeated resource when it is the only content
+// of the value of a template marker.  An empty string is returned otherwise.
+func (tm *TemplateMarker) getLoopVariable() string {
+	if len(tm.references) != 1 || !tm.references[0].Repeat {
+		return ""
+	}
+
+	if templateReferenceRegex.FindString(tm.Value) != tm.Value {
+		return ""
+	}
+
+	return tm.references[0].GetSourceCodeVariable()
+}
+
+// writeLiteral writes the literal text between the field references of a template marker
+// to a format string.
+func (tm *TemplateMarker) writeLiteral(format *strings.Builder, literal string) error {
+	if strings.Contains(literal, "{{") || strings.Contains(literal, "}}") {
+		return fmt.Errorf("%w; %s contains an invalid field reference in %q", ErrTemplateMarkerInvalid, tm, literal)
+	}
+
+	format.WriteString(strings.ReplaceAll(literal, "%", "%%"))
+
+	return nil
+}
+
+// newTemplateReference returns a new template reference given the path of a reference
+// from the value of a template marker.
+func newTemplateReference(path string) *TemplateReference {
+	if strings.HasPrefix(path, templateRepeatPrefix) {
+		return &TemplateReference{
+			Name:   strings.TrimPrefix(path, templateRepeatPrefix),
+			Repeat: true,
+		}
+	}
+
+	if strings.HasPrefix(path, templateCollectionPrefix) {
+		return &TemplateReference{
+			Name:       strings.TrimPrefix(path, templateCollectionPrefix),
+			Collection: true,
+		}
+	}
+
+	return &TemplateReference{Name: path}
+}
+
+// GetName returns the name of the field referenced by a template reference.
+func (tr *TemplateReference) GetName() string {
+	return tr.Name
+}
+
+// GetSpecPrefix returns the spec prefix of the field referenced by a template reference.
+func (tr *TemplateReference) GetSpecPrefix() string {
+	if tr.Collection {
+		return CollectionFieldSpecPrefix
+	}
+
+	return FieldSpecPrefix
+}
+
+// GetSourceCodeVariable returns the variable of the field referenced by a template reference
+// as it is intended to be scaffolded in the source code.  An empty string is returned for an
+// unknown loop variable of a repeated resource.
+func (tr *TemplateReference) GetSourceCodeVariable() string {
+	if tr.Repeat {
+		switch tr.Name {
+		case templateRepeatIndex:
+			return RepeatIndexVariable
+		case templateRepeatItem:
+			return RepeatItemVariable
+		default:
+			return ""
+		}
+	}
+
+	return getSourceCodeVariable(tr)
+}
+
+//nolint:gocritic //needed to implement string interface
+func (tr TemplateReference) String() string {
+	if tr.Repeat {
+		return templateRepeatPrefix + tr.Name
+	}
+
+	if tr.Collection {
+		return templateCollectionPrefix + tr.Name
+	}
+
+	return tr.Name
+}
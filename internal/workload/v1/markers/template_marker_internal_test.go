@@ -0,0 +1,125 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateMarker_setSourceCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		value          string
+		wantSourceCode string
+		wantReferences []*TemplateReference
+		wantErr        bool
+	}{
+		{
+			name:           "template with field references",
+			value:          "{{ .environment }}-{{ .appName }}",
+			wantSourceCode: `fmt.Sprintf("%v-%v", parent.Spec.Environment, parent.Spec.AppName)`,
+			wantReferences: []*TemplateReference{
+				{Name: "environment"},
+				{Name: "appName"},
+			},
+		},
+		{
+			name:           "template with nested and collection field references",
+			value:          "{{.app.name}}.{{ .collection.domain }}",
+			wantSourceCode: `fmt.Sprintf("%v.%v", parent.Spec.App.Name, collection.Spec.Domain)`,
+			wantReferences: []*TemplateReference{
+				{Name: "app.name"},
+				{Name: "domain", Collection: true},
+			},
+		},
+		{
+			name:           "template with percent literal",
+			value:          "{{ .percent }}%",
+			wantSourceCode: `fmt.Sprintf("%v%%", parent.Spec.Percent)`,
+			wantReferences: []*TemplateReference{
+				{Name: "percent"},
+			},
+		},
+		{
+			name:           "template with repeat references",
+			value:          "{{ .repeat.item }}-{{ .repeat.index }}",
+			wantSourceCode: `fmt.Sprintf("%v-%v", repeatItem, repeatIndex)`,
+			wantReferences: []*TemplateReference{
+				{Name: "item", Repeat: true},
+				{Name: "index", Repeat: true},
+			},
+		},
+		{
+			name:    "template with unknown repeat reference",
+			value:   "{{ .repeat.key }}",
+			wantErr: true,
+		},
+		{
+			name:    "template without field references",
+			value:   "static-value",
+			wantErr: true,
+		},
+		{
+			name:    "template with invalid field reference",
+			value:   "{{ .environment }}-{{ appName }}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tm := &TemplateMarker{Value: tt.value}
+			if err := tm.setSourceCode(); (err != nil) != tt.wantErr {
+				t.Errorf("TemplateMarker.setSourceCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantSourceCode, tm.GetSourceCode())
+			assert.Equal(t, tt.wantReferences, tm.GetReferences())
+		})
+	}
+}
+
+func TestTemplateReference_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reference TemplateReference
+		want      string
+	}{
+		{
+			name:      "field reference",
+			reference: TemplateReference{Name: "appName"},
+			want:      "appName",
+		},
+		{
+			name:      "collection field reference",
+			reference: TemplateReference{Name: "domain", Collection: true},
+			want:      "collection.domain",
+		},
+		{
+			name:      "repeat reference",
+			reference: TemplateReference{Name: "index", Repeat: true},
+			want:      "repeat.index",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.reference.String())
+		})
+	}
+}
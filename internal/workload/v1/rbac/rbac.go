@@ -87,6 +87,18 @@ func ForWorkloads(workloads ...rbacWorkloadProcessor) *Rules {
 	return rules
 }
 
+// ForSecretReferences will return a set of rules which allow the controller to read the secrets that
+// are referenced by the sensitive fields of a workload.
+func ForSecretReferences() *Rules {
+	return &Rules{
+		{
+			Group:    coreGroup,
+			Resource: "secrets",
+			Verbs:    []string{"get", "list", "watch"},
+		},
+	}
+}
+
 // getGroup returns the group in the proper format as expected by rbac markers.
 func getGroup(group string) string {
 	if group == "" {
@@ -48,6 +48,7 @@ func NewKubebuilderCLI() (*kbcli.CLI, error) {
 		kbcli.WithDefaultProjectVersion(cfgv3.Version),
 		kbcli.WithExtraCommands(NewUpdateCmd()),
 		kbcli.WithExtraCommands(NewInitConfigCmd()),
+		kbcli.WithExtraCommands(NewLintCmd()),
 		kbcli.WithCompletion(),
 	)
 	if err != nil {
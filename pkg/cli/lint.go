@@ -0,0 +1,58 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrLintMissingWorkloadConfig = errors.New("a workload config must be provided with --workload-config")
+
+func NewLintCmd() *cobra.Command {
+	var workloadConfigPath string
+
+	var maxErrors int
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate a workload configuration and its manifests",
+		Long: `Validate a workload configuration, the manifests which it references and the markers
+within them, without scaffolding a project.  Each problem is printed along with its location
+and the command exits with a non-zero status when any problem is found.`,
+		Example:      "  operator-builder lint --workload-config .source-manifests/workload.yaml",
+		SilenceUsage: true,
+		// the error is reported once by the caller of the command line interface
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if workloadConfigPath == "" {
+				return ErrLintMissingWorkloadConfig
+			}
+
+			problems, truncated := subcommand.Lint(workloadConfigPath, maxErrors)
+			for _, problem := range problems {
+				fmt.Fprintln(cmd.OutOrStdout(), problem)
+			}
+
+			if truncated {
+				fmt.Fprintf(cmd.OutOrStdout(), "too many problems, stopped after the first %d problems\n", maxErrors)
+			}
+
+			if len(problems) > 0 {
+				return fmt.Errorf("%w; %d problem(s) found in %s", subcommand.ErrLintProblems, len(problems), workloadConfigPath)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workloadConfigPath, "workload-config", "", "path to workload config file")
+	cmd.Flags().IntVar(&maxErrors, "max-errors", 0, "maximum number of problems to report, or 0 to report all problems")
+
+	return cmd
+}
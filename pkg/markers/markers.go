@@ -0,0 +1,71 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package markers
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1/markers"
+)
+
+// Definition defines a custom marker, which is found within the manifests of each workload along
+// with the built-in markers.
+type Definition struct {
+	// Name is the name of the marker, which is followed by its arguments within a manifest,
+	// e.g. +acme:labels.  The name must begin with a '+' and may not begin with +operator-builder,
+	// which is reserved for the built-in markers.
+	Name string
+
+	// Object is a value of the struct type into which the arguments of the marker are parsed.
+	// Each exported field is an argument, named after the field in lower camel case unless it is
+	// named by a `marker:"name"` tag, and is required unless it is a pointer or is tagged with
+	// `marker:",optional"`.
+	Object interface{}
+
+	// Transform is called for each of the markers which is found within the manifests.
+	Transform TransformFunc
+}
+
+// TransformFunc transforms the YAML which is marked by a custom marker.  It is called before the
+// source code of the child resources is generated from the manifests, so any change which it
+// makes to the nodes of the result is included within the generated child resources.
+type TransformFunc func(result *Result) error
+
+// Result is a custom marker which was found within a manifest.
+type Result struct {
+	// Object is the marker, as a value of the struct type of the Object of its definition, with
+	// its fields set from the arguments of the marker.
+	Object interface{}
+
+	// MarkerText is the text of the marker, as it was found within the manifest.
+	MarkerText string
+
+	// Nodes are the YAML nodes which are marked.  A marker on a mapping key marks both the key
+	// and its value, in that order, while a marker on any other node marks only that node.
+	Nodes []*yaml.Node
+}
+
+// Register registers a custom marker.  Custom markers must be registered before the manifests
+// are processed, e.g. before the operator-builder command line interface is run by a custom
+// build of operator-builder.
+func Register(definition Definition) error {
+	transform := definition.Transform
+	if transform == nil {
+		return fmt.Errorf("%w for custom marker %s", markers.ErrCustomMarkerInvalidTransform, definition.Name)
+	}
+
+	if err := markers.RegisterCustomMarker(
+		definition.Name,
+		definition.Object,
+		func(object interface{}, markerText string, nodes []*yaml.Node) error {
+			return transform(&Result{Object: object, MarkerText: markerText, Nodes: nodes})
+		},
+	); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}